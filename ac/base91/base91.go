@@ -5,7 +5,9 @@
 package base91 // import "catinello.eu/base91"
 
 // Encoding table holds all the characters for base91 encoding - slice is faster than an array.
-var enctab = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#$%&()*+,./:;<=>?@[]^_`{|}~'")
+// The last character is the double-quote, as in the original basE91 alphabet and as in dectab.
+// Upstream uses a single-quote that dectab rejects: the encoded output differs whenever the digit 90 occurs.
+var enctab = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#$%&()*+,./:;<=>?@[]^_`{|}~\"")
 
 // Decoding table maps all the characters back to their integer values - array is faster than a map
 // This array represents all 91 characters with values below 91.
@@ -15,10 +15,10 @@ var samples = map[string]string{
 	"abcdefghijklmnopqurstuvwxyz": "#G(Ic,5ph#77&xrmlrjg2]jTs%2<WF%qfB",
 }
 
-// init inserts a failing case in samples.
-func Disable_init() {
+// init inserts a case using the last character of the alphabet.
+func init() {
 	const hexa = "5526a41a95041b"
-	const str = ":Ro7<O'9B"
+	const str = `:Ro7<O"9B`
 	b, err := hex.DecodeString(hexa)
 	if err != nil {
 		panic(err)
@@ -47,3 +47,22 @@ func TestDecode(t *testing.T) {
 		}
 	}
 }
+
+// TestLastChar checks enctab and dectab agree on the digit 90.
+func TestLastChar(t *testing.T) {
+	if c := enctab[90]; c != '"' {
+		t.Errorf("enctab[90] = %q, want %q", c, '"')
+	}
+	if v := dectab['"']; v != 90 {
+		t.Errorf("dectab['\"'] = %d, want 90", v)
+	}
+	if v := dectab['\'']; v != 91 {
+		t.Errorf("dectab['\\''] = %d, want 91 (invalid)", v)
+	}
+
+	for i, c := range enctab {
+		if v := dectab[c]; int(v) != i {
+			t.Errorf("dectab[%q] = %d, want %d", c, v, i)
+		}
+	}
+}
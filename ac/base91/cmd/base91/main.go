@@ -1,125 +1,303 @@
 // Copyright (c) 2019-2021 Antonino Catinello
+// Copyright (c) 2022      Teal.Finance contributors
 // SPDX-License-Identifier: BSD-3-Clause
 
 // Command line tool to de-/encode base91.
+//
+// The input is streamed: files of any size and lines of any length
+// are supported. The decoded output is written as raw bytes.
+//
+// Exit codes: 0 on success, 1 on input/output or decoding error,
+// and 2 on invalid command line usage.
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
+	"runtime/debug"
 
 	// "catinello.eu/base91"
 	"github.com/teal-finance/BaseXX/ac/base91"
 )
 
+// version can be set at build time:
+//
+//	go build -ldflags "-X main.version=v1.2.3"
 var version string
 
-const wrap int = 127 // 127 + add newline char
+const defaultWrap = 127 // 127 + add newline char
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
 
 func main() {
-	alen := len(os.Args)
-
-	if alen >= 2 {
-		// decode
-		if os.Args[1] == "-d" || os.Args[1] == "--decode" {
-			if alen == 3 {
-				if os.Args[2] != "-" {
-					content, err := ioutil.ReadFile(os.Args[2])
-					if err != nil {
-						// error
-						fmt.Fprintln(os.Stderr, err.Error())
-					} else {
-						fmt.Print(base91.Decode(content))
-					}
-				} else if os.Args[2] == "-" {
-					var input []byte
-					scanner := bufio.NewScanner(os.Stdin)
-					for scanner.Scan() {
-						input = append(input, scanner.Bytes()...)
-					}
-
-					if err := scanner.Err(); err != nil {
-						fmt.Println(err)
-					}
-
-					fmt.Print(base91.Decode(input))
-				}
-			} else {
-				// help
-				help()
-			}
-		} else if os.Args[1] == "-h" {
-			// help
-			help()
-		} else {
-			// encode
-			content, err := ioutil.ReadFile(os.Args[1])
-			if err != nil {
-				// error
-				fmt.Fprintln(os.Stderr, err.Error())
-			} else {
-				fmt.Print(newLine(base91.EncodeToString(content)))
-			}
-		}
-	} else if alen == 1 {
-		var input []byte
-		scanner := bufio.NewScanner(os.Stdin)
-		for scanner.Scan() {
-			input = append(input, scanner.Bytes()...)
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type options struct {
+	decode  bool
+	ignore  bool
+	help    bool
+	license bool
+	version bool
+	wrap    int
+	output  string
+	input   string
+}
+
+func parse(args []string) (*options, error) {
+	var opt options
+
+	fs := flag.NewFlagSet("base91", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&opt.decode, "d", false, "")
+	fs.BoolVar(&opt.decode, "decode", false, "")
+	fs.BoolVar(&opt.ignore, "i", false, "")
+	fs.BoolVar(&opt.ignore, "ignore-garbage", false, "")
+	fs.IntVar(&opt.wrap, "w", defaultWrap, "")
+	fs.IntVar(&opt.wrap, "wrap", defaultWrap, "")
+	fs.StringVar(&opt.output, "o", "-", "")
+	fs.StringVar(&opt.output, "output", "-", "")
+	fs.BoolVar(&opt.help, "h", false, "")
+	fs.BoolVar(&opt.help, "help", false, "")
+	fs.BoolVar(&opt.license, "license", false, "")
+	fs.BoolVar(&opt.version, "version", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch fs.NArg() {
+	case 0:
+		opt.input = "-"
+	case 1:
+		opt.input = fs.Arg(0)
+	default:
+		return nil, fmt.Errorf("extra operand %q", fs.Arg(1))
+	}
+
+	if opt.wrap < 0 {
+		return nil, fmt.Errorf("invalid wrap size: %d", opt.wrap)
+	}
+
+	return &opt, nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opt, err := parse(args)
+	if err != nil {
+		fmt.Fprintln(stderr, "base91:", err)
+		fmt.Fprintln(stderr, "Try 'base91 --help' for more information.")
+		return exitUsage
+	}
+
+	switch {
+	case opt.help:
+		help(stdout)
+		return exitOK
+	case opt.license:
+		fmt.Fprint(stdout, license)
+		return exitOK
+	case opt.version:
+		fmt.Fprintln(stdout, getVersion())
+		return exitOK
+	}
+
+	if err := convert(opt, stdin, stdout); err != nil {
+		fmt.Fprintln(stderr, "base91:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func convert(opt *options, stdin io.Reader, stdout io.Writer) (err error) {
+	in := stdin
+	if opt.input != "-" {
+		f, err := os.Open(opt.input)
+		if err != nil {
+			return err
 		}
+		defer f.Close()
+		in = f
+	}
 
-		if err := scanner.Err(); err != nil {
-			fmt.Println(err)
+	out := stdout
+	if opt.output != "-" {
+		f, err := os.Create(opt.output)
+		if err != nil {
+			return err
 		}
+		defer func() {
+			if e := f.Close(); err == nil {
+				err = e
+			}
+		}()
+		out = f
+	}
+
+	if opt.decode {
+		return decode(in, out, opt.ignore)
+	}
+	return encode(in, out, opt.wrap)
+}
 
-		fmt.Println(newLine(base91.EncodeToString(input)))
+func decode(in io.Reader, out io.Writer, ignoreGarbage bool) error {
+	var dec io.Reader
+	if ignoreGarbage {
+		dec = base91.NewDecoder(in)
 	} else {
-		// help
-		help()
+		dec = base91.NewStrictDecoder(in)
+	}
+
+	_, err := io.Copy(out, dec)
+	return err
+}
+
+func encode(in io.Reader, out io.Writer, wrap int) error {
+	ww := &wrapWriter{w: out, width: wrap}
+	enc := base91.NewEncoder(ww)
+
+	if _, err := io.Copy(enc, in); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
 	}
+	return ww.Close()
 }
 
-func help() {
-	fmt.Println("base91 - Binary to ASCII text encoding.")
-	fmt.Println()
-	fmt.Println("Usage:")
-	fmt.Println("  base91 [OPTIONS] <FILE>")
-	fmt.Println()
-	fmt.Println("Parameter:")
-	fmt.Println("  <FILE>               		| Path to file or - for stdin.")
-	fmt.Println()
-	fmt.Println("Options:")
-	fmt.Println("  -d | --decode <FILE> 		| Decode mode.")
-	fmt.Println()
-	fmt.Println("  --help               		| Show this help.")
-	fmt.Println("  --license            		| Print license.")
-	fmt.Println("  --version            		| Print version.")
-	fmt.Println()
-	fmt.Println("Examples:")
-	fmt.Println("  echo \"bla\"  | base91		| Encode")
-	fmt.Println("  echo \"<izI\" | base91 -d - 	| Decode")
-	fmt.Println()
-	fmt.Println("Website:")
-	fmt.Println("  https://codeberg.org/ac/base91")
-	fmt.Println()
-	fmt.Println("License:")
-	fmt.Println("  BSD License ©  Antonino Catinello")
-	fmt.Println()
-	fmt.Println("Version:")
-	fmt.Println("  " + version)
+// wrapWriter inserts a newline every width bytes
+// and terminates the last line on Close.
+type wrapWriter struct {
+	w     io.Writer
+	width int
+	col   int
+	any   bool
 }
 
-func newLine(content string) string {
-	var tmp string
+func (ww *wrapWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		ww.any = true
+	}
+
+	if ww.width == 0 {
+		return ww.w.Write(p)
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if ww.col == ww.width {
+			if _, err := ww.w.Write([]byte{'\n'}); err != nil {
+				return written, err
+			}
+			ww.col = 0
+		}
+
+		n := ww.width - ww.col
+		if n > len(p) {
+			n = len(p)
+		}
 
-	for i := 0; i < len(content); i++ {
-		if i%wrap == 0 && i != 0 {
-			tmp = tmp + "\n"
+		n, err := ww.w.Write(p[:n])
+		written += n
+		ww.col += n
+		if err != nil {
+			return written, err
 		}
-		tmp = tmp + string(content[i])
+		p = p[n:]
 	}
 
-	return tmp
+	return written, nil
 }
+
+// Close terminates the last line, if any. The underlying writer is not closed.
+func (ww *wrapWriter) Close() error {
+	if !ww.any {
+		return nil
+	}
+	_, err := ww.w.Write([]byte{'\n'})
+	return err
+}
+
+func getVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func help(w io.Writer) {
+	fmt.Fprintln(w, "base91 - Binary to ASCII text encoding.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  base91 [OPTIONS] [FILE]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Parameter:")
+	fmt.Fprintln(w, "  FILE                     Path to file, or - for stdin (default).")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Options:")
+	fmt.Fprintln(w, "  -d, --decode             Decode mode.")
+	fmt.Fprintln(w, "  -i, --ignore-garbage     When decoding, ignore non-alphabet characters.")
+	fmt.Fprintf(w, "  -w, --wrap N             Wrap encoded lines after N characters (default %d).\n", defaultWrap)
+	fmt.Fprintln(w, "                           Use 0 to disable line wrapping.")
+	fmt.Fprintln(w, "  -o, --output FILE        Write to FILE instead of stdout.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  -h, --help               Show this help.")
+	fmt.Fprintln(w, "      --license            Print license.")
+	fmt.Fprintln(w, "      --version            Print version.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Examples:")
+	fmt.Fprintln(w, "  echo \"bla\"  | base91          Encode")
+	fmt.Fprintln(w, "  echo \"<izI\" | base91 -d       Decode")
+	fmt.Fprintln(w, "  base91 -w 0 -o out.b91 file   Encode file on a single line")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit status:")
+	fmt.Fprintln(w, "  0 on success, 1 on input/output or decoding error, 2 on usage error.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Website:")
+	fmt.Fprintln(w, "  https://codeberg.org/ac/base91")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "License:")
+	fmt.Fprintln(w, "  BSD License ©  Antonino Catinello")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Version:")
+	fmt.Fprintln(w, "  "+getVersion())
+}
+
+const license = `Copyright (c) 2019, Antonino Catinello
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its
+   contributors may be used to endorse or promote products derived from
+   this software without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+`
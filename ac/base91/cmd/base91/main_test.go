@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_run(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		stdin  string
+		stdout string
+		code   int
+	}{
+		{"encode", nil, "1234567890", "QztEml0o[2;(A\n", exitOK},
+		{"encode-empty", nil, "", "", exitOK},
+		{"encode-wrap", []string{"-w", "5"}, "1234567890", "QztEm\nl0o[2\n;(A\n", exitOK},
+		{"encode-long-wrap", []string{"--wrap=4"}, "1234567890", "QztE\nml0o\n[2;(\nA\n", exitOK},
+		{"encode-no-wrap", []string{"--wrap", "0"}, "1234567890", "QztEml0o[2;(A\n", exitOK},
+		{"decode", []string{"-d"}, "QztEml0o[2;(A\n", "1234567890", exitOK},
+		{"decode-stdin", []string{"--decode", "-"}, "QztE\nml0o\n[2;(\nA\n", "1234567890", exitOK},
+		{"decode-binary", []string{"-d"}, "xA", "1", exitOK},
+		{"decode-garbage", []string{"-d"}, "Qzt Eml0o[2;(A", "1", exitError}, // streamed up to the garbage
+		{"decode-ignore-garbage", []string{"-d", "-i"}, "Qzt Eml0o[2;(A", "1234567890", exitOK},
+		{"help", []string{"--help"}, "", "", exitOK},
+		{"license", []string{"--license"}, "", license, exitOK},
+		{"bad-flag", []string{"--bad"}, "", "", exitUsage},
+		{"bad-wrap", []string{"-w", "-1"}, "", "", exitUsage},
+		{"extra-operand", []string{"a", "b"}, "", "", exitUsage},
+		{"missing-file", []string{"/does/not/exist"}, "", "", exitError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(c.args, strings.NewReader(c.stdin), &stdout, &stderr)
+			if code != c.code {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, c.code, stderr.String())
+			}
+			if c.name != "help" && stdout.String() != c.stdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), c.stdout)
+			}
+		})
+	}
+}
+
+func Test_run_files(t *testing.T) {
+	dir := t.TempDir()
+	bin := make([]byte, 200*1024) // larger than the 64 KB limit of bufio.Scanner
+	for i := range bin {
+		bin[i] = byte(i * 7)
+	}
+
+	in := filepath.Join(dir, "in.bin")
+	if err := os.WriteFile(in, bin, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	enc := filepath.Join(dir, "in.b91")
+	if code := run([]string{"-w", "0", "-o", enc, in}, nil, nil, os.Stderr); code != exitOK {
+		t.Fatalf("encode exit code = %d", code)
+	}
+
+	dec := filepath.Join(dir, "out.bin")
+	if code := run([]string{"-d", "-o", dec, enc}, nil, nil, os.Stderr); code != exitOK {
+		t.Fatalf("decode exit code = %d", code)
+	}
+
+	got, err := os.ReadFile(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, bin) {
+		t.Error("decoded file differs from the original one")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2019-2021 Antonino Catinello
+// Copyright (c) 2022      Teal.Finance contributors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package base91
+
+import (
+	"io"
+	"strconv"
+)
+
+// CorruptInputError reports the offset of a character
+// that does not belong to the base91 alphabet.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "illegal base91 data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// bufSize is the size of the chunks read and written by the streams.
+const bufSize = 32 * 1024
+
+type encoder struct {
+	w   io.Writer
+	err error
+	b   uint
+	n   uint
+	out []byte
+}
+
+// NewEncoder returns a new base91 stream encoder.
+// Data written to the returned writer is encoded and written to w.
+// The caller must Close the returned encoder to flush the pending bits.
+func NewEncoder(w io.Writer) io.WriteCloser {
+	return &encoder{w: w, out: make([]byte, 0, bufSize)}
+}
+
+// Write encodes p and writes the complete encoded pairs to the underlying writer.
+func (e *encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	for i, c := range p {
+		e.b |= uint(c) << e.n
+		e.n += 8
+
+		if e.n > 13 {
+			v := e.b & 8191
+
+			if v > 88 {
+				e.b >>= 13
+				e.n -= 13
+			} else {
+				v = e.b & 16383
+				e.b >>= 14
+				e.n -= 14
+			}
+
+			e.out = append(e.out, enctab[v%91], enctab[v/91])
+
+			if len(e.out) >= bufSize-1 {
+				if e.flush(); e.err != nil {
+					return i + 1, e.err
+				}
+			}
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close writes the remaining bits. It does not close the underlying writer.
+func (e *encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.n > 0 {
+		e.out = append(e.out, enctab[e.b%91])
+
+		if e.n > 7 || e.b > 90 {
+			e.out = append(e.out, enctab[e.b/91])
+		}
+
+		e.b, e.n = 0, 0
+	}
+
+	e.flush()
+	return e.err
+}
+
+func (e *encoder) flush() {
+	if len(e.out) == 0 {
+		return
+	}
+	_, e.err = e.w.Write(e.out)
+	e.out = e.out[:0]
+}
+
+type decoder struct {
+	r      io.Reader
+	err    error
+	strict bool
+	offset int64 // number of input bytes already consumed
+	b      uint
+	n      uint
+	v      int
+	in     []byte
+	buf    []byte
+	out    []byte // decoded bytes not yet returned by Read
+}
+
+// NewDecoder returns a new base91 stream decoder reading from r.
+// As Decode does, the characters outside the base91 alphabet are skipped.
+func NewDecoder(r io.Reader) io.Reader {
+	return &decoder{r: r, v: -1, in: make([]byte, bufSize)}
+}
+
+// NewStrictDecoder is like NewDecoder but returns a CorruptInputError
+// when the input contains a character outside the base91 alphabet,
+// except the line breaks "\n" and "\r" that are always skipped.
+func NewStrictDecoder(r io.Reader) io.Reader {
+	return &decoder{r: r, v: -1, in: make([]byte, bufSize), strict: true}
+}
+
+// Read decodes the input stream into p.
+func (d *decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		n, err := d.r.Read(d.in)
+		d.decode(d.in[:n])
+
+		if err == io.EOF && d.err == nil {
+			if d.v > -1 {
+				d.out = append(d.out, byte((d.b|uint(d.v)<<d.n)&255))
+				d.v = -1
+			}
+			d.err = io.EOF
+		} else if err != nil && d.err == nil {
+			d.err = err
+		}
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+func (d *decoder) decode(src []byte) {
+	// d.out is empty here, so its backing array can be reused
+	d.out = d.buf[:0]
+	defer func() { d.buf = d.out }()
+
+	for i, char := range src {
+		c := dectab[char]
+		if c > 90 {
+			if d.strict && char != '\n' && char != '\r' {
+				d.err = CorruptInputError(d.offset + int64(i))
+				return
+			}
+			continue
+		}
+
+		if d.v < 0 {
+			d.v = int(c)
+			continue
+		}
+
+		d.v += int(c) * 91
+		d.b |= uint(d.v) << d.n
+
+		if d.v&8191 > 88 {
+			d.n += 13
+		} else {
+			d.n += 14
+		}
+
+		for {
+			d.out = append(d.out, byte(d.b&255))
+			d.b >>= 8
+			d.n -= 8
+
+			if d.n <= 7 {
+				break
+			}
+		}
+
+		d.v = -1
+	}
+
+	d.offset += int64(len(src))
+}
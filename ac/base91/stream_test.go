@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package base91
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamEncoder(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 13, 100, bufSize - 1, bufSize, 3*bufSize + 7} {
+		bin := make([]byte, size)
+		if _, err := rand.Read(bin); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		// write in small uneven chunks to exercise the pending bits
+		for i := 0; i < size; i += 5 {
+			end := i + 5
+			if end > size {
+				end = size
+			}
+			if _, err := enc.Write(bin[i:end]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := Encode(bin); !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("size=%d: stream encoding differs from Encode()", size)
+		}
+	}
+}
+
+func TestStreamDecoder(t *testing.T) {
+	for bin, s := range samples {
+		got, err := io.ReadAll(NewDecoder(strings.NewReader(s)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, []byte(bin)) {
+			t.Errorf("Incorrect decoding of %q", s)
+			t.Errorf("want: %x", bin)
+			t.Errorf("got : %x", got)
+		}
+	}
+
+	bin := make([]byte, 3*bufSize+7)
+	if _, err := rand.Read(bin); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(NewStrictDecoder(bytes.NewReader(Encode(bin))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, bin) {
+		t.Error("stream decoding differs from the original data")
+	}
+}
+
+func TestStrictDecoder(t *testing.T) {
+	got, err := io.ReadAll(NewStrictDecoder(strings.NewReader("Qzt\nEml0o\r\n[2;(A\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "1234567890" {
+		t.Errorf("want 1234567890 but got %q", got)
+	}
+
+	_, err = io.ReadAll(NewStrictDecoder(strings.NewReader("Qzt Eml0o")))
+	var e CorruptInputError
+	if !errors.As(err, &e) || e != 3 {
+		t.Errorf("want CorruptInputError(3) but got %v", err)
+	}
+
+	got, err = io.ReadAll(NewDecoder(strings.NewReader("Qzt Eml0o [2;(A")))
+	if err != nil || string(got) != "1234567890" {
+		t.Errorf("lenient decoder: got %q, %v", got, err)
+	}
+}
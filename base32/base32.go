@@ -0,0 +1,511 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Package base32 implements the Base32 family of encodings
+// (5 bytes into 8 characters) with the same API as z85:
+//
+//   - StdEncoding and HexEncoding as specified by RFC 4648,
+//   - CrockfordEncoding (https://www.crockford.com/base32.html),
+//     case-insensitive and with an optional check symbol,
+//   - ZBase32Encoding, the human-oriented z-base-32.
+//
+// StdEncoding and HexEncoding produce the same output as "encoding/base32".
+package base32
+
+import (
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+const Radix = 32
+
+const (
+	StdPadding rune = '=' // Standard padding character
+	NoPadding  rune = -1  // No padding
+)
+
+// StdEncoding is the standard Base32 encoding, as defined in RFC 4648.
+var StdEncoding = NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")
+
+// HexEncoding is the "Extended Hex Alphabet" defined in RFC 4648,
+// preserving the sort order of the binary data.
+var HexEncoding = NewEncoding("0123456789ABCDEFGHIJKLMNOPQRSTUV")
+
+// ZBase32Encoding is the z-base-32 encoding, without padding,
+// using an alphabet designed to be easily read, written and spoken.
+var ZBase32Encoding = NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(NoPadding)
+
+// CrockfordEncoding is the Douglas Crockford's Base32 encoding, without padding.
+// Decoding is case-insensitive: "I" and "L" are read as "1",
+// "O" is read as "0", and the hyphens are ignored.
+// Use CrockfordEncoding.WithCheck() to append and verify a check symbol.
+var CrockfordEncoding = newCrockford()
+
+// The extra symbols of the Crockford check symbol (values 32 to 36).
+const checkSymbols = "*~$=U"
+
+func newCrockford() *Encoding {
+	enc := NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").
+		WithPadding(NoPadding).
+		CaseInsensitive().
+		WithAliases(map[byte]byte{'I': '1', 'i': '1', 'L': '1', 'l': '1', 'O': '0', 'o': '0'}).
+		WithIgnore("-")
+	return enc
+}
+
+// Encoding is a Base32 encoding with a configurable alphabet.
+type Encoding struct {
+	alphabet *encoding.Encoding
+	padChar  rune
+	check    bool // append the Crockford check symbol
+}
+
+// NewEncoding returns a new padded Encoding using the given 32-character alphabet.
+// It panics if the alphabet does not contain 32 distinct ASCII characters.
+func NewEncoding(encoder string) *Encoding {
+	return &Encoding{
+		alphabet: encoding.NewEncoding(encoder, Radix),
+		padChar:  StdPadding,
+	}
+}
+
+// WithPadding returns a copy of the encoding using the given padding character,
+// or NoPadding to disable the padding.
+// It panics if the padding character is not ASCII
+// or belongs to the alphabet.
+func (enc Encoding) WithPadding(padding rune) *Encoding {
+	if padding != NoPadding {
+		if padding < 0 || padding > 127 || padding == '\r' || padding == '\n' {
+			log.Panicf("Base%d: invalid padding character %q", Radix, padding)
+		}
+		if enc.alphabet.DecMap[padding] != -1 {
+			log.Panicf("Base%d: padding character %q belongs to the alphabet", Radix, padding)
+		}
+		if enc.alphabet.Ignored(byte(padding)) {
+			log.Panicf("Base%d: padding character %q is an ignored character", Radix, padding)
+		}
+	}
+	enc.padChar = padding
+	return &enc
+}
+
+// WithCheck returns a copy of the encoding appending a check symbol
+// to the encoded data and verifying it when decoding.
+// The check symbol is the value of the encoded digits modulo 37,
+// as specified by Crockford: the alphabet is extended by "*~$=U".
+// The empty input is encoded without check symbol.
+// It panics if the encoding is padded.
+func (enc Encoding) WithCheck() *Encoding {
+	if enc.padChar != NoPadding {
+		log.Panicf("Base%d: the check symbol requires an encoding without padding", Radix)
+	}
+	enc.check = true
+	return &enc
+}
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+// It also panics if an alias is the padding character.
+func (enc Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	if _, ok := aliases[byte(enc.padChar)]; ok && enc.padChar != NoPadding {
+		log.Panicf("Base%d: alias %q is the padding character", Radix, enc.padChar)
+	}
+	enc.alphabet = enc.alphabet.WithAliases(aliases)
+	return &enc
+}
+
+// CaseInsensitive returns a copy of the encoding
+// decoding the letters in both lower and upper cases.
+func (enc Encoding) CaseInsensitive() *Encoding {
+	enc.alphabet = enc.alphabet.CaseInsensitive()
+	return &enc
+}
+
+// WithGroups returns a copy of the encoding emitting the characters
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+// It also panics if separator contains the padding character.
+func (enc Encoding) WithGroups(size int, separator string) *Encoding {
+	enc.checkIgnore(separator)
+	enc.alphabet = enc.alphabet.WithGroups(size, separator)
+	return &enc
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+func (enc Encoding) WithWrap(width int) *Encoding {
+	enc.alphabet = enc.alphabet.WithWrap(width)
+	return &enc
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+// It also panics if chars contains the padding character.
+func (enc Encoding) WithIgnore(chars string) *Encoding {
+	enc.checkIgnore(chars)
+	enc.alphabet = enc.alphabet.WithIgnore(chars)
+	return &enc
+}
+
+func (enc *Encoding) checkIgnore(chars string) {
+	for _, c := range chars {
+		if c == enc.padChar {
+			log.Panicf("Base%d: ignored character %q is the padding character", Radix, c)
+		}
+	}
+}
+
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding { return enc.alphabet }
+
+// CorruptInputError reports the offset of an invalid Base32 character.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "illegal base32 data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// Bytes is a byte slice appearing as a Base32 string (StdEncoding)
+// in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return StdEncoding }
+
+// Flag is a command-line parameter given as a Base32 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
+// Encode encodes src into EncodedLen(len(src)) bytes of dst
+// and returns the number of written bytes.
+// A trailing partial block is completed by padding characters,
+// if the encoding is padded.
+func (enc *Encoding) Encode(dst, src []byte) int {
+	n := 0
+	for len(src) > 0 {
+		var block [5]byte
+		size := copy(block[:], src)
+		src = src[size:]
+
+		v := uint64(block[0])<<32 | uint64(block[1])<<24 | uint64(block[2])<<16 | uint64(block[3])<<8 | uint64(block[4])
+
+		chars := (size*8 + 4) / 5
+		for i := 0; i < chars; i++ {
+			dst[n+i] = enc.alphabet.EncChars[(v>>(35-5*i))&0x1f]
+		}
+		n += chars
+
+		if enc.padChar != NoPadding {
+			for i := chars; i < 8; i++ {
+				dst[n] = byte(enc.padChar)
+				n++
+			}
+		}
+	}
+
+	if enc.check && n > 0 {
+		dst[n] = checkSymbol(enc, dst[:n])
+		n++
+	}
+
+	return enc.alphabet.Format(dst, n)
+}
+
+// checkSymbol returns the Crockford check symbol of the encoded digits.
+func checkSymbol[T string | []byte](enc *Encoding, digits T) byte {
+	mod := 0
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if enc.alphabet.Ignored(c) {
+			continue
+		}
+		mod = (mod*Radix + int(enc.alphabet.DecMap[c])) % 37
+	}
+	if mod < Radix {
+		return enc.alphabet.EncChars[mod]
+	}
+	return checkSymbols[mod-Radix]
+}
+
+// Decode decodes src into DecodedLen(len(src)) bytes of dst
+// and returns the number of written bytes.
+// The returned error is a CorruptInputError
+// on invalid character, invalid length, invalid padding
+// or wrong check symbol,
+// or io.ErrShortBuffer when dst is too short for the decoded data.
+// Decode is DecodePartial with flush set to true.
+func (enc *Encoding) Decode(dst, src []byte) (int, error) {
+	n, nsrc, err := enc.DecodePartial(dst, src, true)
+	if err == nil && nsrc < len(src) {
+		err = io.ErrShortBuffer
+	}
+	return n, err
+}
+
+// DecodePartial decodes src into dst, returning both the number
+// of bytes written to dst and the number consumed from src,
+// as ascii85.Decode does.
+// It stops when dst has not enough room for the next block,
+// or, if flush is false, after the padding characters that end the encoded data.
+//
+// If flush is false, the trailing partial block is not decoded (nor consumed):
+// the caller is expected to call again DecodePartial with the
+// remaining input followed by the next chunk of input.
+// If flush is true, src is the end of the input stream:
+// the trailing partial block is decoded
+// and only ignored characters may follow the padding.
+//
+// The check symbol can only be verified on the whole input:
+// with an encoding having a check symbol (see WithCheck),
+// DecodePartial decodes nothing until flush is true.
+//
+// On error, ndst and nsrc report the data decoded so far.
+func (enc *Encoding) DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error) {
+	if dst == nil {
+		dst = []byte{} // no room, a nil dst would only count the decoded bytes
+	}
+	return decodePartial(enc, dst, src, flush)
+}
+
+// decodeString decodes the whole s as DecodePartial with flush set to true,
+// this is the encoding.DecodeFunc of DecodeString, DecodeInto and Valid.
+func (enc *Encoding) decodeString(dst []byte, s string) (ndst, nsrc int, err error) {
+	return decodePartial(enc, dst, s, true)
+}
+
+// decodePartial is DecodePartial for both strings and byte slices.
+// A nil dst only validates src: ndst counts the decoded bytes.
+func decodePartial[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	if !enc.check {
+		return decodeBlocks(enc, dst, src, flush)
+	}
+
+	if !flush {
+		return 0, 0, nil
+	}
+
+	end := len(src) - 1
+	for end >= 0 && enc.alphabet.Ignored(src[end]) {
+		end--
+	}
+	if end < 0 {
+		return 0, len(src), nil
+	}
+
+	ndst, nsrc, err = decodeBlocks(enc, dst, src[:end], true)
+	if err != nil || nsrc < end {
+		return ndst, nsrc, err
+	}
+
+	// normalize the case and the aliases of the check symbol
+	c := src[end]
+	switch {
+	case c == 'u':
+		c = 'U'
+	case c < 128 && enc.alphabet.DecMap[c] != -1:
+		c = enc.alphabet.EncChars[enc.alphabet.DecMap[c]]
+	}
+	if c != checkSymbol(enc, src[:end]) {
+		return ndst, nsrc, CorruptInputError(end)
+	}
+
+	return ndst, len(src), nil
+}
+
+// decodeBlocks is decodePartial without the check symbol.
+func decodeBlocks[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	var v uint64
+	nb := 0
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		if rune(c) == enc.padChar && nb > 0 {
+			// the padding completes the block and ends the encoded data
+			end := i
+			for pads := nb; pads < 8; end++ {
+				if end == len(src) {
+					if !flush {
+						return ndst, nsrc, nil
+					}
+					return ndst, nsrc, CorruptInputError(len(src))
+				}
+				if enc.alphabet.Ignored(src[end]) {
+					continue
+				}
+				if rune(src[end]) != enc.padChar {
+					return ndst, nsrc, CorruptInputError(end)
+				}
+				pads++
+			}
+			n, err := decodeTail(tail(dst, ndst), v, nb, i)
+			if err != nil || n < 0 {
+				return ndst, nsrc, err
+			}
+			ndst += n
+			if !flush {
+				return ndst, end, nil
+			}
+			for j := end; j < len(src); j++ {
+				if !enc.alphabet.Ignored(src[j]) {
+					return ndst, end, CorruptInputError(j) // data after the padding
+				}
+			}
+			return ndst, len(src), nil
+		}
+
+		if enc.alphabet.Ignored(c) {
+			continue
+		}
+
+		if c > 127 || enc.alphabet.DecMap[c] == -1 {
+			return ndst, nsrc, CorruptInputError(i)
+		}
+
+		v = v<<5 | uint64(enc.alphabet.DecMap[c])
+		nb++
+
+		if nb == 8 {
+			if dst != nil && len(dst)-ndst < 5 {
+				return ndst, nsrc, nil
+			}
+			if dst != nil {
+				dst[ndst] = byte(v >> 32)
+				dst[ndst+1] = byte(v >> 24)
+				dst[ndst+2] = byte(v >> 16)
+				dst[ndst+3] = byte(v >> 8)
+				dst[ndst+4] = byte(v)
+			}
+			ndst += 5
+			nsrc = i + 1
+			v = 0
+			nb = 0
+		}
+	}
+
+	if nb == 0 {
+		return ndst, len(src), nil
+	}
+
+	if !flush {
+		return ndst, nsrc, nil
+	}
+
+	if enc.padChar != NoPadding {
+		return ndst, nsrc, CorruptInputError(len(src)) // missing padding
+	}
+
+	n, err := decodeTail(tail(dst, ndst), v, nb, len(src))
+	if err != nil || n < 0 {
+		return ndst, nsrc, err
+	}
+	return ndst + n, len(src), nil
+}
+
+// decodeTail writes the bytes of the trailing partial block
+// made of nb characters having the value v.
+// It returns -1 if dst has not enough room, a nil dst only counts the bytes.
+// offset is the position of the block end within the input.
+func decodeTail(dst []byte, v uint64, nb, offset int) (int, error) {
+	// only 2, 4, 5 and 7 characters encode a whole number of bytes
+	size := nb * 5 / 8
+	if nb != (size*8+4)/5 {
+		return 0, CorruptInputError(offset)
+	}
+	if dst == nil {
+		return size, nil
+	}
+	if len(dst) < size {
+		return -1, nil
+	}
+
+	v <<= 5 * (8 - nb)
+	for i := 0; i < size; i++ {
+		dst[i] = byte(v >> (32 - 8*i))
+	}
+	return size, nil
+}
+
+// tail returns dst[ndst:], keeping a nil dst nil.
+func tail(dst []byte, ndst int) []byte {
+	if dst == nil {
+		return nil
+	}
+	return dst[ndst:]
+}
+
+// EncodeToString returns the Base32 encoding of src.
+func (enc *Encoding) EncodeToString(src []byte) string {
+	dst := make([]byte, enc.EncodedLen(len(src)))
+	n := enc.Encode(dst, src)
+	return string(dst[:n])
+}
+
+// DecodeString returns the bytes represented by the Base32 string s.
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(s)))
+	n, nsrc, err := enc.decodeString(dst, s)
+	if err == nil && nsrc < len(s) {
+		err = io.ErrShortBuffer
+	}
+	return dst[:n], err
+}
+
+// DecodeBytes returns the bytes represented by the Base32 bytes src,
+// allocating the destination buffer at the right size.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(dst, src)
+	return dst[:n], err
+}
+
+// DecodeInto decodes the Base32 string s into dst,
+// requiring exactly len(dst) decoded bytes, else the error is an *encoding.LengthError.
+// The content of dst is unspecified when an error occurs.
+func (enc *Encoding) DecodeInto(dst []byte, s string) error {
+	return encoding.DecodeBlocksInto(enc.decodeString, Radix, dst, s)
+}
+
+// Valid reports whether s is a valid Base32 string,
+// without allocating memory (see encoding.ValidBlocks).
+func (enc *Encoding) Valid(s string) bool {
+	_, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok
+}
+
+// ValidFor reports whether s is a valid Base32 string
+// decoding into at most n bytes, without allocating memory (see Valid).
+func (enc *Encoding) ValidFor(s string, n int) bool {
+	size, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok && size <= n
+}
+
+// EncodedLen returns the length in bytes of the Base32 encoding of n bytes,
+// including the padding and the check symbol, if any.
+func (enc *Encoding) EncodedLen(n int) int {
+	size := (n*8 + 4) / 5
+	if enc.padChar != NoPadding {
+		size = (n + 4) / 5 * 8
+	}
+	if enc.check && n > 0 {
+		size++
+	}
+	return enc.alphabet.FormattedLen(size)
+}
+
+// DecodedLen returns the maximum length in bytes of the data
+// decoded from n Base32-encoded bytes.
+func (enc *Encoding) DecodedLen(n int) int {
+	if enc.padChar != NoPadding {
+		return n / 8 * 5
+	}
+	return n * 5 / 8
+}
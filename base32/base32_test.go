@@ -0,0 +1,302 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package base32
+
+import (
+	"bytes"
+	stdBase32 "encoding/base32"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// Test vectors from RFC 4648, section 10.
+var rfcCases = []struct {
+	bin, std, hex string
+}{
+	{"", "", ""},
+	{"f", "MY======", "CO======"},
+	{"fo", "MZXQ====", "CPNG===="},
+	{"foo", "MZXW6===", "CPNMU==="},
+	{"foob", "MZXW6YQ=", "CPNMUOG="},
+	{"fooba", "MZXW6YTB", "CPNMUOJ1"},
+	{"foobar", "MZXW6YTBOI======", "CPNMUOJ1E8======"},
+}
+
+func TestRFC(t *testing.T) {
+	for _, c := range rfcCases {
+		for _, tc := range []struct {
+			enc *Encoding
+			str string
+		}{{StdEncoding, c.std}, {HexEncoding, c.hex}} {
+			if got := tc.enc.EncodeToString([]byte(c.bin)); got != tc.str {
+				t.Errorf("EncodeToString(%q) = %q, want %q", c.bin, got, tc.str)
+			}
+			got, err := tc.enc.DecodeString(tc.str)
+			if err != nil || string(got) != c.bin {
+				t.Errorf("DecodeString(%q) = %q, %v, want %q", tc.str, got, err, c.bin)
+			}
+		}
+	}
+}
+
+func TestSameAsStdlib(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		bin := make([]byte, rand.Intn(100))
+		rand.Read(bin)
+
+		for _, c := range []struct {
+			enc *Encoding
+			std *stdBase32.Encoding
+		}{
+			{StdEncoding, stdBase32.StdEncoding},
+			{HexEncoding, stdBase32.HexEncoding},
+			{StdEncoding.WithPadding(NoPadding), stdBase32.StdEncoding.WithPadding(stdBase32.NoPadding)},
+			{HexEncoding.WithPadding('@'), stdBase32.HexEncoding.WithPadding('@')},
+		} {
+			str := c.enc.EncodeToString(bin)
+			if want := c.std.EncodeToString(bin); str != want {
+				t.Fatalf("EncodeToString(%x) = %q, want %q", bin, str, want)
+			}
+			if len(str) != c.enc.EncodedLen(len(bin)) {
+				t.Fatalf("EncodedLen(%d) = %d, want %d", len(bin), c.enc.EncodedLen(len(bin)), len(str))
+			}
+
+			got, err := c.enc.DecodeString(str)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("round-trip of %x = %x, %v", bin, got, err)
+			}
+		}
+	}
+}
+
+func TestZBase32(t *testing.T) {
+	cases := []struct {
+		bin, str string
+	}{
+		{"", ""},
+		{"\x00", "yy"},
+		{"\xf0\xbf\xc7", "6n9hq"},
+		{"\xd4\x7a\x04", "4t7ye"},
+	}
+
+	for _, c := range cases {
+		if got := ZBase32Encoding.EncodeToString([]byte(c.bin)); got != c.str {
+			t.Errorf("EncodeToString(%q) = %q, want %q", c.bin, got, c.str)
+		}
+		got, err := ZBase32Encoding.DecodeString(c.str)
+		if err != nil || string(got) != c.bin {
+			t.Errorf("DecodeString(%q) = %q, %v, want %q", c.str, got, err, c.bin)
+		}
+	}
+}
+
+func TestCrockford(t *testing.T) {
+	bin := []byte{0x8a, 0x0f, 0x50, 0x12, 0x34}
+
+	str := CrockfordEncoding.EncodeToString(bin)
+	if str != "H87N04HM" {
+		t.Errorf("EncodeToString(%x) = %q, want %q", bin, str, "H87N04HM")
+	}
+
+	// case-insensitive, aliases and hyphens
+	for _, s := range []string{"H87N04HM", "h87n04hm", "H87N-O4HM", "H87N-o4-HM"} {
+		got, err := CrockfordEncoding.DecodeString(s)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Errorf("DecodeString(%q) = %x, %v, want %x", s, got, err, bin)
+		}
+	}
+
+	got, err := CrockfordEncoding.DecodeString("iI-lL")
+	if want := []byte{0x08, 0x42}; err != nil || !bytes.Equal(got, want) {
+		t.Errorf("DecodeString(iI-lL) = %x, %v, want %x", got, err, want)
+	}
+
+	// the aliases do not alter the other encodings
+	if _, err := HexEncoding.DecodeString("cpnmuoj1"); err == nil {
+		t.Errorf("HexEncoding must not decode lower case")
+	}
+}
+
+func TestCrockfordCheck(t *testing.T) {
+	enc := CrockfordEncoding.WithCheck()
+
+	cases := []struct {
+		bin []byte
+		str string
+	}{
+		{nil, ""},
+		{[]byte{0x01}, "044"},
+		{[]byte{0xff}, "ZWN"},
+		{[]byte{0x08}, "10*"},
+	}
+
+	for _, c := range cases {
+		if got := enc.EncodeToString(c.bin); got != c.str {
+			t.Errorf("EncodeToString(%x) = %q, want %q", c.bin, got, c.str)
+		}
+		got, err := enc.DecodeString(c.str)
+		if err != nil || !bytes.Equal(got, c.bin) {
+			t.Errorf("DecodeString(%q) = %x, %v, want %x", c.str, got, err, c.bin)
+		}
+	}
+
+	if got, err := enc.DecodeString("zw-n"); err != nil || !bytes.Equal(got, []byte{0xff}) {
+		t.Errorf("DecodeString(zw-n) = %x, %v, want ff", got, err)
+	}
+
+	var e CorruptInputError
+	if _, err := enc.DecodeString("ZWM"); !errors.As(err, &e) || e != 2 {
+		t.Errorf("DecodeString(ZWM) error = %v, want CorruptInputError(2)", err)
+	}
+
+	for i := 0; i < 200; i++ {
+		bin := make([]byte, rand.Intn(40))
+		rand.Read(bin)
+		got, err := enc.DecodeString(enc.EncodeToString(bin))
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("round-trip of %x = %x, %v", bin, got, err)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	cases := []struct {
+		enc    *Encoding
+		str    string
+		offset CorruptInputError
+	}{
+		{StdEncoding, "MZXW6YQ=", -1},
+		{StdEncoding, "MZXW6YQ", 7},          // missing padding
+		{StdEncoding, "MZXW6Y==", 6},         // 6 characters cannot be decoded
+		{StdEncoding, "MZXW6YQ=MZXW6YQ=", 8}, // data after the padding
+		{StdEncoding, "MZXW6=Q=", 6},         // padding interrupted
+		{StdEncoding, "MZXW1YQ=", 4},         // not in the alphabet
+		{StdEncoding, "mzxw6yq=", 0},         // case-sensitive
+		{StdEncoding, "MZXW\xffYQ=", 4},      // non-ASCII
+		{ZBase32Encoding, "yyy", 3},
+	}
+
+	for _, c := range cases {
+		_, err := c.enc.DecodeString(c.str)
+		if c.offset < 0 {
+			if err != nil {
+				t.Errorf("DecodeString(%q) unexpected error %v", c.str, err)
+			}
+			continue
+		}
+		var e CorruptInputError
+		if !errors.As(err, &e) || e != c.offset {
+			t.Errorf("DecodeString(%q) error = %v, want CorruptInputError(%d)", c.str, err, c.offset)
+		}
+	}
+}
+
+func TestDecode_ShortBuffer(t *testing.T) {
+	cases := []struct {
+		enc *Encoding
+		str string
+		n   int // size of dst
+	}{
+		{StdEncoding, "MZXW6YTBOI======", 0},
+		{StdEncoding, "MZXW6YTBOI======", 5},
+		{StdEncoding, "MZXW6YTB", 4},
+		{CrockfordEncoding, "CSQPYRK1", 4},
+		{CrockfordEncoding.WithCheck(), "CSQPYRK1Z", 4},
+	}
+
+	for _, c := range cases {
+		_, err := c.enc.Decode(make([]byte, c.n), []byte(c.str))
+		if !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("Decode(%d bytes, %q) error = %v, want io.ErrShortBuffer", c.n, c.str, err)
+		}
+	}
+
+	// only ignored characters may follow the padding
+	enc := StdEncoding.WithIgnore("\n")
+	if n, err := enc.Decode(make([]byte, 6), []byte("MZXW6YTBOI======\n")); n != 6 || err != nil {
+		t.Errorf("Decode(MZXW6YTBOI======) = %d, %v, want 6, nil", n, err)
+	}
+	var e CorruptInputError
+	if _, err := enc.Decode(make([]byte, 6), []byte("MZXW6YTBOI======\nA")); !errors.As(err, &e) || e != 17 {
+		t.Errorf("Decode(MZXW6YTBOI======A) error = %v, want CorruptInputError(17)", err)
+	}
+}
+
+func TestDecodePartial(t *testing.T) {
+	cases := []struct {
+		enc        *Encoding
+		str        string
+		dstLen     int
+		flush      bool
+		ndst, nsrc int
+	}{
+		{StdEncoding, "MZXW6YTBOI======", 10, false, 6, 16},
+		{StdEncoding, "MZXW6YTBOI===", 10, false, 5, 8},  // the padding waits for more input
+		{StdEncoding, "MZXW6YTBOI======", 5, true, 5, 8}, // dst too small
+		{CrockfordEncoding, "H87N04HM04", 10, false, 5, 8},
+		{CrockfordEncoding, "H87N04HM04", 10, true, 6, 10},
+		{CrockfordEncoding.WithCheck(), "044", 10, false, 0, 0},
+	}
+
+	for _, c := range cases {
+		dst := make([]byte, c.dstLen)
+		ndst, nsrc, err := c.enc.DecodePartial(dst, []byte(c.str), c.flush)
+		if err != nil || ndst != c.ndst || nsrc != c.nsrc {
+			t.Errorf("DecodePartial(%q, flush=%v) = %d, %d, %v, want %d, %d, nil",
+				c.str, c.flush, ndst, nsrc, err, c.ndst, c.nsrc)
+		}
+	}
+}
+
+func TestWithPadding_Panics(t *testing.T) {
+	for _, padding := range []rune{'A', '\n', 200} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("WithPadding(%q): expected panic did not occur", padding)
+				}
+			}()
+			StdEncoding.WithPadding(padding)
+		}()
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	enc := StdEncoding.CaseInsensitive()
+
+	got, err := enc.DecodeString("mzxW6ytboi======")
+	if err != nil || string(got) != "foobar" {
+		t.Errorf("DecodeString() = %q, %v, want foobar", got, err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on alias of the padding character did not occur")
+		}
+	}()
+	StdEncoding.WithAliases(map[byte]byte{'=': 'A'})
+}
+
+func TestWithGroups(t *testing.T) {
+	enc := StdEncoding.WithGroups(4, "-").WithWrap(7)
+
+	str := enc.EncodeToString([]byte("foobar"))
+	if want := "MZXW-6Y\nTB-OI==\n-===="; str != want {
+		t.Errorf("EncodeToString() = %q, want %q", str, want)
+	}
+
+	got, err := enc.DecodeString(str)
+	if err != nil || string(got) != "foobar" {
+		t.Errorf("DecodeString(%q) = %q, %v, want foobar", str, got, err)
+	}
+
+	check := CrockfordEncoding.WithCheck().WithGroups(4, "-")
+	str = check.EncodeToString([]byte{0x8a, 0x0f, 0x50, 0x12, 0x34})
+	if want := "H87N-04HM-N"; str != want {
+		t.Errorf("EncodeToString() = %q, want %q", str, want)
+	}
+}
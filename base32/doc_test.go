@@ -0,0 +1,36 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package base32_test
+
+import (
+	"fmt"
+
+	"github.com/teal-finance/BaseXX/base32"
+)
+
+// Encode binary data as specified by RFC 4648.
+func ExampleEncoding_EncodeToString() {
+	str := base32.StdEncoding.EncodeToString([]byte("foobar"))
+
+	fmt.Println("Base32 string:", str)
+	// Output:
+	// Base32 string: MZXW6YTBOI======
+}
+
+// Decode a Crockford string read over the phone:
+// the case, the hyphens and the confusing letters do not matter.
+func ExampleEncoding_DecodeString() {
+	enc := base32.CrockfordEncoding.WithCheck()
+
+	str := enc.EncodeToString([]byte{0x8a, 0x0f, 0x50, 0x12, 0x34})
+	bin, err := enc.DecodeString("h87n-o4hm-" + str[len(str)-1:])
+
+	fmt.Println("Crockford:", str)
+	fmt.Printf("Binary:    %x\n", bin)
+	fmt.Println("Error:    ", err)
+	// Output:
+	// Crockford: H87N04HMN
+	// Binary:    8a0f501234
+	// Error:     <nil>
+}
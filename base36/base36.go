@@ -0,0 +1,246 @@
+// Copyright (c) 2017-2020 Denis Subbotin, Philip Schlump,
+//                         Nika Jones, Steven Allen, MoonFruit
+// Copyright (c) 2022      Teal.Finance contributors
+//
+// This file is a modified copy from https://github.com/mr-tron/base58
+// The source code has been adapted to support other bases.
+// This file is now part of BaseXX under the terms of the MIT License.
+// SPDX-License-Identifier: MIT
+// See the LICENSE file or https://opensource.org/licenses/MIT
+
+// Package base36 is a pretty good Base36 encoder
+// with customizable encoding alphabet.
+package base36
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+const Radix = 36
+
+const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// StdEncoding is the default encoding enc.
+var StdEncoding = NewEncoding(alphabet)
+
+type Encoding encoding.Encoding
+
+func NewEncoding(encoder string) *Encoding {
+	e := encoding.NewEncoding(encoder, Radix)
+	return (*Encoding)(e)
+}
+
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding {
+	return (*encoding.Encoding)(enc)
+}
+
+// Bytes is a byte slice appearing as a Base36 string (StdEncoding)
+// in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return StdEncoding }
+
+// Flag is a command-line parameter given as a Base36 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+func (enc *Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithAliases(aliases))
+}
+
+// WithLeadingZeros returns a copy of the encoding handling the leading zero bytes
+// as selected by mode. See encoding.LeadingZeros.
+func (enc *Encoding) WithLeadingZeros(mode encoding.LeadingZeros) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithLeadingZeros(mode))
+}
+
+// Sortable returns a copy of the encoding emitting fixed-width strings
+// sorting in the same order as the binary data of the same length.
+// It panics if the alphabet is not in ascending order.
+// See encoding.Encoding.Sortable.
+func (enc *Encoding) Sortable() *Encoding {
+	return (*Encoding)(enc.Alphabet().Sortable())
+}
+
+// WithGroups returns a copy of the encoding emitting the digits
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+func (enc *Encoding) WithGroups(size int, separator string) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithGroups(size, separator))
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+func (enc *Encoding) WithWrap(width int) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithWrap(width))
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+func (enc *Encoding) WithIgnore(chars string) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithIgnore(chars))
+}
+
+// EncodeUUID encodes a 16-byte UUID into exactly 25 Base36 characters,
+// whatever its leading zero bytes. See encoding.Encoding.EncodeUUID.
+func (enc *Encoding) EncodeUUID(uuid [16]byte) string {
+	return enc.Alphabet().EncodeUUID(uuid)
+}
+
+// DecodeUUID decodes a UUID encoded by EncodeUUID
+// or given in its canonical hyphenated text representation.
+// See encoding.Encoding.DecodeUUID.
+func (enc *Encoding) DecodeUUID(str string) ([16]byte, error) {
+	return enc.Alphabet().DecodeUUID(str)
+}
+
+// Valid reports whether str is a valid Base36 string, without allocating memory.
+// See encoding.Encoding.Valid.
+func (enc *Encoding) Valid(str string) bool {
+	return enc.Alphabet().Valid(str)
+}
+
+// ValidFor reports whether str is a valid Base36 string
+// decoding into at most n bytes. See encoding.Encoding.ValidFor.
+func (enc *Encoding) ValidFor(str string, n int) bool {
+	return enc.Alphabet().ValidFor(str, n)
+}
+
+// DecodeInto decodes a Base36 string into dst without allocating memory,
+// requiring exactly len(dst) decoded bytes. See encoding.Encoding.DecodeInto.
+func (enc *Encoding) DecodeInto(dst []byte, str string) error {
+	return enc.Alphabet().DecodeInto(dst, str)
+}
+
+// EncodeUint64 encodes v into Base36 digits, as EncodeToString
+// encodes the minimal big-endian bytes of v. See encoding.Encoding.EncodeUint64.
+func (enc *Encoding) EncodeUint64(v uint64) string {
+	return enc.Alphabet().EncodeUint64(v)
+}
+
+// DecodeUint64 decodes Base36 digits into an unsigned 64-bit integer.
+// See encoding.Encoding.DecodeUint64.
+func (enc *Encoding) DecodeUint64(str string) (uint64, error) {
+	return enc.Alphabet().DecodeUint64(str)
+}
+
+// EncodeBigInt encodes a non-negative x into Base36 digits,
+// as EncodeToString encodes x.Bytes(). See encoding.Encoding.EncodeBigInt.
+func (enc *Encoding) EncodeBigInt(x *big.Int) string {
+	return enc.Alphabet().EncodeBigInt(x)
+}
+
+// DecodeBigInt decodes Base36 digits into an arbitrary large integer.
+// See encoding.Encoding.DecodeBigInt.
+func (enc *Encoding) DecodeBigInt(str string) (*big.Int, error) {
+	return enc.Alphabet().DecodeBigInt(str)
+}
+
+// CaseInsensitive returns a copy of the encoding
+// decoding the letters in both lower and upper cases.
+func (enc *Encoding) CaseInsensitive() *Encoding {
+	return (*Encoding)(enc.Alphabet().CaseInsensitive())
+}
+
+// EncodeToString encodes binary bytes into Base36 bytes.
+func (enc *Encoding) EncodeToString(bin []byte) string {
+	return string(enc.Encode(bin))
+}
+
+// EncodeToString encodes binary bytes into a Base36 string.
+func (enc *Encoding) Encode(bin []byte) []byte {
+	switch alphabet := enc.Alphabet(); alphabet.LeadingZeros() {
+	case encoding.LengthPreserving:
+		return alphabet.Formatted(alphabet.EncodeFixed(bin))
+	case encoding.IntegerZeros:
+		bin = encoding.TrimZeros(bin)
+	}
+
+	// convert the bytes into digits, then the digits into characters in-place
+	out := encoding.ConvertRadix(bin, 256, Radix)
+	for i, d := range out {
+		out[i] = enc.EncChars[d]
+	}
+
+	return enc.Alphabet().Formatted(out)
+}
+
+// DecodeString decodes a Base36 string into binary bytes.
+func (enc *Encoding) DecodeString(str string) ([]byte, error) {
+	return decode(enc, str)
+}
+
+// DecodeBytes decodes Base36 bytes into binary bytes
+// without converting src into a string.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	return decode(enc, src)
+}
+
+// Decode decodes src into dst and returns the number of written bytes.
+// dst must have at least DecodedLen(len(src)) bytes.
+func (enc *Encoding) Decode(dst, src []byte) (int, error) {
+	bin, err := decode(enc, src)
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < len(bin) {
+		return 0, fmt.Errorf("Base%d: dst has %d bytes, decoded data has %d bytes", Radix, len(dst), len(bin))
+	}
+	return copy(dst, bin), nil
+}
+
+// DecodedLen returns the maximum length in bytes
+// of the data decoded from n Base36 characters:
+// each leading zero digit may be a zero byte.
+func (enc *Encoding) DecodedLen(n int) int {
+	return n
+}
+
+// decode is shared by DecodeString and DecodeBytes.
+func decode[T string | []byte](enc *Encoding, str T) ([]byte, error) {
+	str = encoding.Strip(enc.Alphabet(), str)
+
+	if enc.Alphabet().LeadingZeros() == encoding.LengthPreserving {
+		return encoding.DecodeFixed(enc.Alphabet(), str)
+	}
+
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	// the aliases are decoded as the digit they stand for
+	digits := make([]byte, len(str))
+	for i := 0; i < len(str); i++ {
+		r := str[i]
+		if r > 127 {
+			return nil, fmt.Errorf("Base%d: high-bit set on invalid digit", Radix)
+		}
+		if enc.DecMap[r] == -1 {
+			return nil, fmt.Errorf("Base%d: invalid digit %q", Radix, r)
+		}
+		digits[i] = byte(enc.DecMap[r])
+	}
+
+	// the leading zero digits do not change the integer value
+	if enc.Alphabet().LeadingZeros() == encoding.IntegerZeros {
+		for len(digits) > 0 && digits[0] == 0 {
+			digits = digits[1:]
+		}
+		if len(digits) == 0 {
+			return nil, nil
+		}
+	}
+
+	return encoding.ConvertRadix(digits, Radix, 256), nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package base36_test
+
+import (
+	"fmt"
+
+	"github.com/teal-finance/BaseXX/base36"
+)
+
+// Encode any binary data to a Base36 string.
+func ExampleEncoding_Encode() {
+	bin := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 255}
+
+	str := base36.StdEncoding.EncodeToString(bin)
+
+	fmt.Println("Binary input: ", bin)
+	fmt.Println("Base36 string:", str)
+	// Output:
+	// Binary input:  [0 1 2 3 4 5 6 7 8 9 255]
+	// Base36 string: 0rwg9z1idsugqv3
+}
+
+// Decode back the encoded Base36 string.
+func ExampleEncoding_DecodeString() {
+	bin, err := base36.StdEncoding.DecodeString("0rwg9z1idsugqv3")
+
+	fmt.Println("Binary:", bin)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Binary: [0 1 2 3 4 5 6 7 8 9 255]
+	// Error:  <nil>
+}
+
+// With custom alphabet.
+func ExampleEncoding_EncodeToString() {
+	myEncoding := base36.NewEncoding(
+		"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+	bin := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255}
+
+	str := myEncoding.EncodeToString(bin)
+	bin, err := myEncoding.DecodeString(str)
+
+	fmt.Println("Binary:", bin)
+	fmt.Println("Base36:", str)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Binary: [0 1 2 3 4 5 6 7 8 9 254 255]
+	// Base36: 05IERQX6QQ54N311B
+	// Error:  <nil>
+}
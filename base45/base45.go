@@ -0,0 +1,296 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Package base45 implements the Base45 encoding specified by RFC 9285
+// (https://www.rfc-editor.org/rfc/rfc9285) with the same API as z85.
+//
+// Base45 encodes 2 bytes into 3 characters of the QR-code alphanumeric mode:
+// the EU Digital COVID Certificates use Base45 to store binary data
+// in QR codes more compactly than Base64 in byte mode.
+package base45
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+const Radix = 45
+
+// The characters of the QR-code alphanumeric mode, including the space.
+const alphabet = "0123456789" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	" $%*+-./:"
+
+// StdEncoding is the Base45 encoding as specified by RFC 9285.
+var StdEncoding = NewEncoding(alphabet)
+
+// Encoding is a Base45 encoding with a configurable alphabet.
+type Encoding struct {
+	alphabet *encoding.Encoding
+}
+
+// NewEncoding returns a new Encoding using the given 45-character alphabet.
+// It panics if the alphabet does not contain 45 distinct ASCII characters.
+func NewEncoding(encoder string) *Encoding {
+	return &Encoding{alphabet: encoding.NewEncoding(encoder, Radix)}
+}
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+func (enc Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	enc.alphabet = enc.alphabet.WithAliases(aliases)
+	return &enc
+}
+
+// CaseInsensitive returns a copy of the encoding
+// decoding the letters in both lower and upper cases.
+func (enc Encoding) CaseInsensitive() *Encoding {
+	enc.alphabet = enc.alphabet.CaseInsensitive()
+	return &enc
+}
+
+// WithGroups returns a copy of the encoding emitting the characters
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+func (enc Encoding) WithGroups(size int, separator string) *Encoding {
+	enc.alphabet = enc.alphabet.WithGroups(size, separator)
+	return &enc
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+func (enc Encoding) WithWrap(width int) *Encoding {
+	enc.alphabet = enc.alphabet.WithWrap(width)
+	return &enc
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+func (enc Encoding) WithIgnore(chars string) *Encoding {
+	enc.alphabet = enc.alphabet.WithIgnore(chars)
+	return &enc
+}
+
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding { return enc.alphabet }
+
+// CorruptInputError reports the offset of an invalid Base45 character or triple.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "illegal base45 data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// Bytes is a byte slice appearing as a Base45 string (StdEncoding)
+// in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return StdEncoding }
+
+// Flag is a command-line parameter given as a Base45 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
+// Encode encodes src into EncodedLen(len(src)) bytes of dst
+// and returns the number of written bytes,
+// including the group separators and the line breaks, if any.
+// Each pair of bytes is encoded into 3 characters,
+// a trailing single byte into 2 characters.
+// As specified by RFC 9285, the least significant digit comes first.
+func (enc *Encoding) Encode(dst, src []byte) int {
+	n := 0
+	for len(src) >= 2 {
+		v := uint(src[0])<<8 | uint(src[1])
+		dst[n] = enc.alphabet.EncChars[v%Radix]
+		dst[n+1] = enc.alphabet.EncChars[v/Radix%Radix]
+		dst[n+2] = enc.alphabet.EncChars[v/(Radix*Radix)]
+		src = src[2:]
+		n += 3
+	}
+
+	if len(src) > 0 {
+		v := uint(src[0])
+		dst[n] = enc.alphabet.EncChars[v%Radix]
+		dst[n+1] = enc.alphabet.EncChars[v/Radix]
+		n += 2
+	}
+
+	return enc.alphabet.Format(dst, n)
+}
+
+// Decode decodes src into DecodedLen(len(src)) bytes of dst
+// and returns the number of written bytes.
+// The returned error is a CorruptInputError
+// on invalid character, out-of-range triple or invalid length,
+// or io.ErrShortBuffer when dst is too short for the decoded data.
+// Decode is DecodePartial with flush set to true.
+func (enc *Encoding) Decode(dst, src []byte) (int, error) {
+	n, nsrc, err := enc.DecodePartial(dst, src, true)
+	if err == nil && nsrc < len(src) {
+		err = io.ErrShortBuffer
+	}
+	return n, err
+}
+
+// DecodePartial decodes src into dst, returning both the number
+// of bytes written to dst and the number consumed from src,
+// as ascii85.Decode does.
+// It stops when dst has not enough room for the next triple.
+//
+// If flush is false, the trailing partial triple is not decoded (nor consumed):
+// the caller is expected to call again DecodePartial with the
+// remaining input followed by the next chunk of input.
+// If flush is true, src is the end of the input stream:
+// the trailing pair of characters is decoded into a single byte.
+//
+// On error, ndst and nsrc report the data decoded so far.
+func (enc *Encoding) DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error) {
+	if dst == nil {
+		dst = []byte{} // no room, a nil dst would only count the decoded bytes
+	}
+	return decodePartial(enc, dst, src, flush)
+}
+
+// decodeString decodes the whole s as DecodePartial with flush set to true,
+// this is the encoding.DecodeFunc of DecodeString, DecodeInto and Valid.
+func (enc *Encoding) decodeString(dst []byte, s string) (ndst, nsrc int, err error) {
+	return decodePartial(enc, dst, s, true)
+}
+
+// decodePartial is DecodePartial for both strings and byte slices.
+// A nil dst only validates src: ndst counts the decoded bytes.
+func decodePartial[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	var v uint
+	weight := uint(1) // the least significant digit comes first
+	nb := 0           // number of characters of the current triple
+	start := 0        // position of the current triple
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if enc.alphabet.Ignored(c) {
+			continue
+		}
+		if c > 127 || enc.alphabet.DecMap[c] == -1 {
+			return ndst, nsrc, CorruptInputError(i)
+		}
+
+		if nb == 0 {
+			start = i
+		}
+		v += weight * uint(enc.alphabet.DecMap[c])
+		weight *= Radix
+		nb++
+
+		if nb == 3 {
+			if dst != nil && len(dst)-ndst < 2 {
+				return ndst, nsrc, nil
+			}
+			if v > 0xffff {
+				return ndst, nsrc, CorruptInputError(start)
+			}
+			if dst != nil {
+				dst[ndst] = byte(v >> 8)
+				dst[ndst+1] = byte(v)
+			}
+			ndst += 2
+			nsrc = i + 1
+			v = 0
+			weight = 1
+			nb = 0
+		}
+	}
+
+	if nb == 0 {
+		return ndst, len(src), nil
+	}
+
+	if !flush {
+		return ndst, nsrc, nil
+	}
+
+	if nb == 1 {
+		return ndst, nsrc, CorruptInputError(start)
+	}
+
+	if dst != nil && len(dst)-ndst < 1 {
+		return ndst, nsrc, nil
+	}
+
+	if v > 0xff {
+		return ndst, nsrc, CorruptInputError(start)
+	}
+
+	if dst != nil {
+		dst[ndst] = byte(v)
+	}
+	return ndst + 1, len(src), nil
+}
+
+// EncodeToString returns the Base45 encoding of src.
+func (enc *Encoding) EncodeToString(src []byte) string {
+	dst := make([]byte, enc.EncodedLen(len(src)))
+	enc.Encode(dst, src)
+	return string(dst)
+}
+
+// DecodeString returns the bytes represented by the Base45 string s.
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(s)))
+	n, nsrc, err := enc.decodeString(dst, s)
+	if err == nil && nsrc < len(s) {
+		err = io.ErrShortBuffer
+	}
+	return dst[:n], err
+}
+
+// DecodeBytes returns the bytes represented by the Base45 bytes src,
+// allocating the destination buffer at the right size.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(dst, src)
+	return dst[:n], err
+}
+
+// DecodeInto decodes the Base45 string s into dst,
+// requiring exactly len(dst) decoded bytes, else the error is an *encoding.LengthError.
+// The content of dst is unspecified when an error occurs.
+func (enc *Encoding) DecodeInto(dst []byte, s string) error {
+	return encoding.DecodeBlocksInto(enc.decodeString, Radix, dst, s)
+}
+
+// Valid reports whether s is a valid Base45 string,
+// without allocating memory (see encoding.ValidBlocks).
+func (enc *Encoding) Valid(s string) bool {
+	_, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok
+}
+
+// ValidFor reports whether s is a valid Base45 string
+// decoding into at most n bytes, without allocating memory (see Valid).
+func (enc *Encoding) ValidFor(s string, n int) bool {
+	size, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok && size <= n
+}
+
+// EncodedLen returns the length in bytes of the Base45 encoding of n bytes,
+// including the group separators and the line breaks, if any.
+func (enc *Encoding) EncodedLen(n int) int {
+	return enc.alphabet.FormattedLen(n/2*3 + n%2*2)
+}
+
+// DecodedLen returns the length in bytes of the data
+// decoded from n Base45-encoded bytes.
+func (enc *Encoding) DecodedLen(n int) int {
+	if n%3 == 2 {
+		return n/3*2 + 1
+	}
+	return n / 3 * 2
+}
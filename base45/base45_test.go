@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package base45
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// Test vectors from RFC 9285, sections 4.3 and 4.4.
+var rfcCases = []struct {
+	bin string
+	str string
+}{
+	{"", ""},
+	{"AB", "BB8"},
+	{"Hello!!", "%69 VD92EX0"},
+	{"base-45", "UJCLQE7W581"},
+	{"ietf!", "QED8WEX0"},
+	{"\x00", "00"},
+	{"\xff", "U5"},
+	{"\xff\xff", "FGW"},
+}
+
+func TestRFC(t *testing.T) {
+	for _, c := range rfcCases {
+		if got := StdEncoding.EncodeToString([]byte(c.bin)); got != c.str {
+			t.Errorf("EncodeToString(%q) = %q, want %q", c.bin, got, c.str)
+		}
+
+		got, err := StdEncoding.DecodeString(c.str)
+		if err != nil || string(got) != c.bin {
+			t.Errorf("DecodeString(%q) = %q, %v, want %q", c.str, got, err, c.bin)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		bin := make([]byte, rand.Intn(100))
+		rand.Read(bin)
+
+		str := StdEncoding.EncodeToString(bin)
+		if len(str) != StdEncoding.EncodedLen(len(bin)) {
+			t.Fatalf("EncodedLen(%d) = %d, want %d", len(bin), StdEncoding.EncodedLen(len(bin)), len(str))
+		}
+		if StdEncoding.DecodedLen(len(str)) != len(bin) {
+			t.Fatalf("DecodedLen(%d) = %d, want %d", len(str), StdEncoding.DecodedLen(len(str)), len(bin))
+		}
+
+		got, err := StdEncoding.DecodeString(str)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("round-trip of %x = %x, %v", bin, got, err)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	cases := []struct {
+		str    string
+		offset CorruptInputError
+	}{
+		{"GGW", 0},       // 65536 is out of range
+		{"BB8GGW", 3},    // out of range after a valid triple
+		{"BB8V5", 3},     // 256 is out of range for the trailing pair
+		{"BB8B", 3},      // one trailing character cannot be decoded
+		{"BB8a", 3},      // lower case is not in the alphabet
+		{"B\xff8", 1},    // non-ASCII
+		{"BB8BB8U5", -1}, // 255 is the highest trailing pair
+	}
+
+	for _, c := range cases {
+		_, err := StdEncoding.DecodeString(c.str)
+		if c.offset < 0 {
+			if err != nil {
+				t.Errorf("DecodeString(%q) unexpected error %v", c.str, err)
+			}
+			continue
+		}
+		var e CorruptInputError
+		if !errors.As(err, &e) || e != c.offset {
+			t.Errorf("DecodeString(%q) error = %v, want CorruptInputError(%d)", c.str, err, c.offset)
+		}
+	}
+}
+
+func TestDecode_ShortBuffer(t *testing.T) {
+	for _, n := range []int{0, 2, 4} {
+		_, err := StdEncoding.Decode(make([]byte, n), []byte("QED8WEX0"))
+		if !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("Decode(%d bytes, QED8WEX0) error = %v, want io.ErrShortBuffer", n, err)
+		}
+	}
+
+	// trailing ignored characters are not an overflow of dst
+	enc := StdEncoding.WithIgnore("\n")
+	if n, err := enc.Decode(make([]byte, 5), []byte("QED8WEX0\n")); n != 5 || err != nil {
+		t.Errorf("Decode(QED8WEX0) = %d, %v, want 5, nil", n, err)
+	}
+}
+
+func TestDecodePartial(t *testing.T) {
+	cases := []struct {
+		str        string
+		dstLen     int
+		flush      bool
+		ndst, nsrc int
+	}{
+		{"BB800", 4, false, 2, 3}, // the trailing pair waits for more input
+		{"BB800", 4, true, 3, 5},
+		{"BB800", 2, true, 2, 3},  // dst too small for the trailing byte
+		{"BB8BB8", 3, true, 2, 3}, // dst too small for the second pair
+	}
+
+	for _, c := range cases {
+		dst := make([]byte, c.dstLen)
+		ndst, nsrc, err := StdEncoding.DecodePartial(dst, []byte(c.str), c.flush)
+		if err != nil || ndst != c.ndst || nsrc != c.nsrc {
+			t.Errorf("DecodePartial(%q, flush=%v) = %d, %d, %v, want %d, %d, nil",
+				c.str, c.flush, ndst, nsrc, err, c.ndst, c.nsrc)
+		}
+	}
+}
+
+func TestWithIgnore(t *testing.T) {
+	enc := StdEncoding.WithIgnore("\r\n").WithWrap(4)
+
+	str := enc.EncodeToString([]byte("Hello!!"))
+	if want := "%69 \nVD92\nEX0"; str != want {
+		t.Errorf("EncodeToString() = %q, want %q", str, want)
+	}
+
+	got, err := enc.DecodeString("%69 VD\r\n92EX0\n")
+	if err != nil || string(got) != "Hello!!" {
+		t.Errorf("DecodeString() = %q, %v, want Hello!!", got, err)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package base45_test
+
+import (
+	"fmt"
+
+	"github.com/teal-finance/BaseXX/base45"
+)
+
+// Encode binary data into the QR-code alphanumeric characters.
+func ExampleEncoding_EncodeToString() {
+	str := base45.StdEncoding.EncodeToString([]byte("Hello!!"))
+
+	fmt.Printf("Base45 string: %q\n", str)
+	// Output:
+	// Base45 string: "%69 VD92EX0"
+}
+
+// Decode a Base45 string.
+func ExampleEncoding_DecodeString() {
+	bin, err := base45.StdEncoding.DecodeString("QED8WEX0")
+
+	fmt.Printf("Binary: %q\n", bin)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Binary: "ietf!"
+	// Error:  <nil>
+}
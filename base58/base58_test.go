@@ -65,3 +65,43 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestWithAliases(t *testing.T) {
+	// the characters excluded from Base58 are read as their look-alike digits
+	enc := StdEncoding.WithAliases(map[byte]byte{'0': 'o', 'O': 'o', 'I': '1', 'l': '1'})
+
+	bin := []byte{0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255}
+	str := StdEncoding.EncodeToString(bin) // "11" + ...
+
+	for _, s := range []string{str, "l" + str[1:], "Il" + str[2:]} {
+		got, err := enc.DecodeString(s)
+		if err != nil || !reflect.DeepEqual(got, bin) {
+			t.Errorf("DecodeString(%q) = %v, %v, want %v", s, got, err, bin)
+		}
+	}
+
+	if _, err := StdEncoding.DecodeString("l" + str[1:]); err == nil {
+		t.Errorf("StdEncoding must not be altered by WithAliases")
+	}
+}
+
+func TestWithGroups(t *testing.T) {
+	enc := StdEncoding.WithGroups(4, "-").WithWrap(10)
+
+	bin := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255}
+	str := enc.EncodeToString(bin)
+	if want := "1FVk-6iLh-\n9oT6-ivJ"; str != want {
+		t.Errorf("EncodeToString() = %q, want %q", str, want)
+	}
+
+	for _, s := range []string{str, "1FVk6iLh9oT6ivJ", "1FVk-6iLh-9oT6-ivJ\r\n"} {
+		got, err := enc.DecodeString(s)
+		if err != nil || !reflect.DeepEqual(got, bin) {
+			t.Errorf("DecodeString(%q) = %v, %v, want %v", s, got, err, bin)
+		}
+	}
+
+	if _, err := StdEncoding.DecodeString(str); err == nil {
+		t.Errorf("StdEncoding must not be altered by WithGroups")
+	}
+}
@@ -4,6 +4,7 @@
 package base58_test
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/teal-finance/BaseXX/base58"
@@ -51,3 +52,34 @@ func ExampleEncoding_EncodeToString() {
 	// Base58: AQeuFsVrJxcFs5T
 	// Error:  <nil>
 }
+
+// Encode a counter without converting it into bytes.
+func ExampleEncoding_EncodeUint64() {
+	str := base58.StdEncoding.EncodeUint64(1_000_000)
+	v, err := base58.StdEncoding.DecodeUint64(str)
+
+	fmt.Println("Base58:", str)
+	fmt.Println("Value: ", v)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Base58: 68GP
+	// Value:  1000000
+	// Error:  <nil>
+}
+
+// Byte slices appearing as Base58 strings in JSON.
+func ExampleBytes() {
+	type Account struct {
+		PublicKey base58.Bytes `json:"public_key"`
+	}
+
+	data, _ := json.Marshal(Account{PublicKey: []byte("Hello World!")})
+	fmt.Println(string(data))
+
+	var a Account
+	err := json.Unmarshal(data, &a)
+	fmt.Printf("%q %v\n", string(a.PublicKey), err)
+	// Output:
+	// {"public_key":"2NEpo7TZRRrLZSi2U"}
+	// "Hello World!" <nil>
+}
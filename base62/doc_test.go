@@ -51,3 +51,18 @@ func ExampleEncoding_EncodeToString() {
 	// Base62: 065EOdIdGZA96TZ
 	// Error:  <nil>
 }
+
+func ExampleEncoding_EncodeUUID() {
+	uuid, _ := base62.StdEncoding.DecodeUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+
+	str := base62.StdEncoding.EncodeUUID(uuid)
+	back, err := base62.StdEncoding.DecodeUUID(str)
+
+	fmt.Println("Base62:", str)
+	fmt.Println("Same:  ", back == uuid)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Base62: 7YBUWgZR1mKSqGyj9tVViw
+	// Same:   true
+	// Error:  <nil>
+}
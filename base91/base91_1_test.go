@@ -197,6 +197,17 @@ func BenchmarkEncoding_DecodeString(b *testing.B) {
 	}
 }
 
+var bAscii [][]byte
+
+func BenchmarkEncoding_DecodeBytes(b *testing.B) {
+	setup(&bAscii, benchEncoding.Encode)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = benchEncoding.DecodeBytes(bAscii[i%nnn])
+	}
+}
+
 var bproctorAscii [][]byte
 
 /* Just to verify "github.com/bproctor/base91"
@@ -14,20 +14,12 @@ package base92
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/teal-finance/BaseXX/encoding"
 )
 
-const (
-	Radix = 92
-	// approximation of ceil(log(256)/log(base)).
-	numerator   = 16 // power of two -> speed up DecodeString()
-	denominator = 13
-)
-
-func init() {
-	encoding.PanicIfBadApproximation(Radix, numerator, denominator)
-}
+const Radix = 92
 
 const alphabet = " !" + // double-quote " removed
 	"#$%&'()*+,-./0123456789:" + // semi-colon ; removed
@@ -37,6 +29,10 @@ const alphabet = " !" + // double-quote " removed
 // StdEncoding is the default encoding enc.
 var StdEncoding = NewEncoding(alphabet)
 
+// SortableEncoding is StdEncoding in sortable mode (its alphabet is in ascending ASCII order):
+// the fixed-width strings sort in the same order as the binary data of the same length.
+var SortableEncoding = StdEncoding.Sortable()
+
 type Encoding encoding.Encoding
 
 func NewEncoding(encoder string) *Encoding {
@@ -44,6 +40,120 @@ func NewEncoding(encoder string) *Encoding {
 	return (*Encoding)(e)
 }
 
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding {
+	return (*encoding.Encoding)(enc)
+}
+
+// Bytes is a byte slice appearing as a Base92 string (StdEncoding)
+// in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return StdEncoding }
+
+// Flag is a command-line parameter given as a Base92 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+func (enc *Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithAliases(aliases))
+}
+
+// WithLeadingZeros returns a copy of the encoding handling the leading zero bytes
+// as selected by mode. See encoding.LeadingZeros.
+func (enc *Encoding) WithLeadingZeros(mode encoding.LeadingZeros) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithLeadingZeros(mode))
+}
+
+// Sortable returns a copy of the encoding emitting fixed-width strings
+// sorting in the same order as the binary data of the same length.
+// It panics if the alphabet is not in ascending order.
+// See encoding.Encoding.Sortable.
+func (enc *Encoding) Sortable() *Encoding {
+	return (*Encoding)(enc.Alphabet().Sortable())
+}
+
+// WithGroups returns a copy of the encoding emitting the digits
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+func (enc *Encoding) WithGroups(size int, separator string) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithGroups(size, separator))
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+func (enc *Encoding) WithWrap(width int) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithWrap(width))
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+func (enc *Encoding) WithIgnore(chars string) *Encoding {
+	return (*Encoding)(enc.Alphabet().WithIgnore(chars))
+}
+
+// EncodeUUID encodes a 16-byte UUID into exactly 20 Base92 characters,
+// whatever its leading zero bytes. See encoding.Encoding.EncodeUUID.
+func (enc *Encoding) EncodeUUID(uuid [16]byte) string {
+	return enc.Alphabet().EncodeUUID(uuid)
+}
+
+// DecodeUUID decodes a UUID encoded by EncodeUUID
+// or given in its canonical hyphenated text representation.
+// See encoding.Encoding.DecodeUUID.
+func (enc *Encoding) DecodeUUID(str string) ([16]byte, error) {
+	return enc.Alphabet().DecodeUUID(str)
+}
+
+// Valid reports whether str is a valid Base92 string, without allocating memory.
+// See encoding.Encoding.Valid.
+func (enc *Encoding) Valid(str string) bool {
+	return enc.Alphabet().Valid(str)
+}
+
+// ValidFor reports whether str is a valid Base92 string
+// decoding into at most n bytes. See encoding.Encoding.ValidFor.
+func (enc *Encoding) ValidFor(str string, n int) bool {
+	return enc.Alphabet().ValidFor(str, n)
+}
+
+// DecodeInto decodes a Base92 string into dst without allocating memory,
+// requiring exactly len(dst) decoded bytes. See encoding.Encoding.DecodeInto.
+func (enc *Encoding) DecodeInto(dst []byte, str string) error {
+	return enc.Alphabet().DecodeInto(dst, str)
+}
+
+// EncodeUint64 encodes v into Base92 digits, as EncodeToString
+// encodes the minimal big-endian bytes of v. See encoding.Encoding.EncodeUint64.
+func (enc *Encoding) EncodeUint64(v uint64) string {
+	return enc.Alphabet().EncodeUint64(v)
+}
+
+// DecodeUint64 decodes Base92 digits into an unsigned 64-bit integer.
+// See encoding.Encoding.DecodeUint64.
+func (enc *Encoding) DecodeUint64(str string) (uint64, error) {
+	return enc.Alphabet().DecodeUint64(str)
+}
+
+// EncodeBigInt encodes a non-negative x into Base92 digits,
+// as EncodeToString encodes x.Bytes(). See encoding.Encoding.EncodeBigInt.
+func (enc *Encoding) EncodeBigInt(x *big.Int) string {
+	return enc.Alphabet().EncodeBigInt(x)
+}
+
+// DecodeBigInt decodes Base92 digits into an arbitrary large integer.
+// See encoding.Encoding.DecodeBigInt.
+func (enc *Encoding) DecodeBigInt(str string) (*big.Int, error) {
+	return enc.Alphabet().DecodeBigInt(str)
+}
+
 // EncodeToString encodes binary bytes into Base92 bytes.
 func (enc *Encoding) EncodeToString(bin []byte) string {
 	return string(enc.Encode(bin))
@@ -51,111 +161,87 @@ func (enc *Encoding) EncodeToString(bin []byte) string {
 
 // EncodeToString encodes binary bytes into a Base92 string.
 func (enc *Encoding) Encode(bin []byte) []byte {
-	size := len(bin)
-
-	zcount := 0
-	for zcount < size && bin[zcount] == 0 {
-		zcount++
+	switch alphabet := enc.Alphabet(); alphabet.LeadingZeros() {
+	case encoding.LengthPreserving:
+		return alphabet.Formatted(alphabet.EncodeFixed(bin))
+	case encoding.IntegerZeros:
+		bin = encoding.TrimZeros(bin)
 	}
 
-	// It is crucial to make this as short as possible, especially for
-	// the usual case of bitcoin addrs
-	size = zcount +
-		// This is an integer simplification of
-		// ceil(log(256)/log(base))
-		(size-zcount)*numerator/denominator + 1
-
-	out := make([]byte, size)
-
-	var i, high int
-	var carry uint32
-
-	high = size - 1
-	for _, b := range bin {
-		i = size - 1
-		for carry = uint32(b); i > high || carry != 0; i-- {
-			carry += 256 * uint32(out[i])
-			out[i] = byte(carry % uint32(Radix))
-			carry /= uint32(Radix)
-		}
-		high = i
+	// convert the bytes into digits, then the digits into characters in-place
+	out := encoding.ConvertRadix(bin, 256, Radix)
+	for i, d := range out {
+		out[i] = enc.EncChars[d]
 	}
 
-	// Determine the additional "zero-gap" in the buffer (aside from zcount)
-	for i = zcount; i < size && out[i] == 0; i++ {
-	}
-
-	// Now encode the values with actual alphabet in-place
-	val := out[i-zcount:]
-	size = len(val)
-	for i = 0; i < size; i++ {
-		out[i] = enc.EncChars[val[i]]
-	}
-
-	return out[:size]
+	return enc.Alphabet().Formatted(out)
 }
 
 // DecodeString decodes a Base92 string into binary bytes.
 func (enc *Encoding) DecodeString(str string) ([]byte, error) {
-	if len(str) == 0 {
-		return nil, nil
-	}
+	return decode(enc, str)
+}
 
-	zero := enc.EncChars[0]
-	strLen := len(str)
+// DecodeBytes decodes Base92 bytes into binary bytes
+// without converting src into a string.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	return decode(enc, src)
+}
 
-	var zcount int
-	for i := 0; i < strLen && str[i] == zero; i++ {
-		zcount++
+// Decode decodes src into dst and returns the number of written bytes.
+// dst must have at least DecodedLen(len(src)) bytes.
+func (enc *Encoding) Decode(dst, src []byte) (int, error) {
+	bin, err := decode(enc, src)
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < len(bin) {
+		return 0, fmt.Errorf("Base%d: dst has %d bytes, decoded data has %d bytes", Radix, len(dst), len(bin))
 	}
+	return copy(dst, bin), nil
+}
+
+// DecodedLen returns the maximum length in bytes
+// of the data decoded from n Base92 characters:
+// each leading zero digit may be a zero byte.
+func (enc *Encoding) DecodedLen(n int) int {
+	return n
+}
+
+// decode is shared by DecodeString and DecodeBytes.
+func decode[T string | []byte](enc *Encoding, str T) ([]byte, error) {
+	str = encoding.Strip(enc.Alphabet(), str)
 
-	var t, c uint64
+	if enc.Alphabet().LeadingZeros() == encoding.LengthPreserving {
+		return encoding.DecodeFixed(enc.Alphabet(), str)
+	}
 
-	// the 32bit algo stretches the result up to 2 times
-	binu := make([]byte, 2*((strLen*denominator/numerator)+1))
-	outi := make([]uint32, (strLen+3)/4)
+	if len(str) == 0 {
+		return nil, nil
+	}
 
-	for _, r := range str {
+	// the aliases are decoded as the digit they stand for
+	digits := make([]byte, len(str))
+	for i := 0; i < len(str); i++ {
+		r := str[i]
 		if r > 127 {
 			return nil, fmt.Errorf("Base%d: high-bit set on invalid digit", Radix)
 		}
 		if enc.DecMap[r] == -1 {
 			return nil, fmt.Errorf("Base%d: invalid digit %q", Radix, r)
 		}
-
-		c = uint64(enc.DecMap[r])
-
-		for j := len(outi) - 1; j >= 0; j-- {
-			t = uint64(outi[j])*uint64(Radix) + c
-			c = t >> 32
-			outi[j] = uint32(t & 0xffffffff)
-		}
+		digits[i] = byte(enc.DecMap[r])
 	}
 
-	// initial mask depends on b92sz, on further loops it always starts at 24 bits
-	mask := (uint(strLen%4) * 8)
-	if mask == 0 {
-		mask = 32
-	}
-	mask -= 8
-
-	outLen := 0
-	for j := 0; j < len(outi); j++ {
-		for mask < 32 { // loop relies on uint overflow
-			binu[outLen] = byte(outi[j] >> mask)
-			mask -= 8
-			outLen++
+	// the leading zero digits do not change the integer value
+	if enc.Alphabet().LeadingZeros() == encoding.IntegerZeros {
+		for len(digits) > 0 && digits[0] == 0 {
+			digits = digits[1:]
 		}
-		mask = 24
-	}
-
-	// find the most significant byte post-decode, if any
-	for msb := zcount; msb < len(binu); msb++ {
-		if binu[msb] > 0 {
-			return binu[msb-zcount : outLen], nil
+		if len(digits) == 0 {
+			return nil, nil
 		}
 	}
 
-	// it's all zeroes
-	return binu[:outLen], nil
+	return encoding.ConvertRadix(digits, Radix, 256), nil
 }
@@ -0,0 +1,221 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	acBase91 "github.com/teal-finance/BaseXX/ac/base91"
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+// codec is the common interface used by the command
+// to encode and decode with any BaseXX encoding.
+type codec interface {
+	encode(bin []byte) []byte
+	decode(txt []byte) ([]byte, error)
+	// valid reports whether c belongs to the encoding alphabet.
+	valid(c byte) bool
+}
+
+// radix is implemented by the Encoding types of the big-integer packages.
+type radix interface {
+	encoding.Encoder
+	Encode(bin []byte) []byte
+	DecodeString(str string) ([]byte, error)
+}
+
+type radixCodec struct{ enc radix }
+
+func (c radixCodec) encode(bin []byte) []byte          { return c.enc.Encode(bin) }
+func (c radixCodec) decode(txt []byte) ([]byte, error) { return c.enc.DecodeString(string(txt)) }
+func (c radixCodec) valid(b byte) bool                 { return b < 128 && c.enc.Alphabet().DecMap[b] != -1 }
+
+type ascii85Codec struct{ enc *xascii85.Encoding }
+
+func (c ascii85Codec) encode(bin []byte) []byte { return []byte(c.enc.EncodeToString(bin)) }
+func (c ascii85Codec) decode(txt []byte) ([]byte, error) {
+	dst := make([]byte, c.enc.DecodedLen(len(txt)))
+	n, err := c.enc.Decode(dst, txt)
+	return dst[:n], err
+}
+func (c ascii85Codec) valid(b byte) bool {
+	return (b < 128 && c.enc.Alphabet().DecMap[b] != -1) || (c.enc == xascii85.StdEncoding && b == 'z')
+}
+
+// block is implemented by the Encoding types of the block-based packages
+// without shortcut characters (base45 and z85).
+type block interface {
+	encoding.Encoder
+	EncodeToString(src []byte) string
+	Decode(dst, src []byte) (int, error)
+	DecodedLen(n int) int
+}
+
+type blockCodec struct{ enc block }
+
+func (c blockCodec) encode(bin []byte) []byte { return []byte(c.enc.EncodeToString(bin)) }
+func (c blockCodec) decode(txt []byte) ([]byte, error) {
+	dst := make([]byte, c.enc.DecodedLen(len(txt)))
+	n, err := c.enc.Decode(dst, txt)
+	return dst[:n], err
+}
+func (c blockCodec) valid(b byte) bool { return b < 128 && c.enc.Alphabet().DecMap[b] != -1 }
+
+// base32Codec also accepts the padding and the Crockford hyphens
+// listed in extra.
+type base32Codec struct {
+	enc   *base32.Encoding
+	extra string
+}
+
+func (c base32Codec) encode(bin []byte) []byte { return []byte(c.enc.EncodeToString(bin)) }
+func (c base32Codec) decode(txt []byte) ([]byte, error) {
+	dst := make([]byte, c.enc.DecodedLen(len(txt)))
+	n, err := c.enc.Decode(dst, txt)
+	return dst[:n], err
+}
+func (c base32Codec) valid(b byte) bool {
+	return (b < 128 && c.enc.Alphabet().DecMap[b] != -1) || strings.IndexByte(c.extra, b) >= 0
+}
+
+type basE91Codec struct{}
+
+func (basE91Codec) encode(bin []byte) []byte          { return acBase91.Encode(bin) }
+func (basE91Codec) decode(txt []byte) ([]byte, error) { return acBase91.Decode(txt), nil }
+func (basE91Codec) valid(b byte) bool {
+	return '!' <= b && b <= '~' && b != '-' && b != '\\' && b != '\''
+}
+
+// bases lists the encodings selectable with --base.
+// The constructor receives the --alphabet value, empty for the default one.
+var bases = map[string]func(alphabet string) (codec, error){
+	"32": func(alphabet string) (codec, error) {
+		enc := base32.StdEncoding
+		if alphabet != "" {
+			enc = base32.NewEncoding(alphabet)
+		}
+		return base32Codec{enc, "="}, nil
+	},
+	"32hex": func(alphabet string) (codec, error) {
+		if alphabet != "" {
+			return nil, errNoAlphabet
+		}
+		return base32Codec{base32.HexEncoding, "="}, nil
+	},
+	"crockford": func(alphabet string) (codec, error) {
+		if alphabet != "" {
+			return nil, errNoAlphabet
+		}
+		return base32Codec{base32.CrockfordEncoding, "-"}, nil
+	},
+	"zbase32": func(alphabet string) (codec, error) {
+		if alphabet != "" {
+			return nil, errNoAlphabet
+		}
+		return base32Codec{base32.ZBase32Encoding, ""}, nil
+	},
+	"36": func(alphabet string) (codec, error) {
+		enc := base36.StdEncoding
+		if alphabet != "" {
+			enc = base36.NewEncoding(alphabet)
+		}
+		return radixCodec{enc}, nil
+	},
+	"45": func(alphabet string) (codec, error) {
+		enc := base45.StdEncoding
+		if alphabet != "" {
+			enc = base45.NewEncoding(alphabet)
+		}
+		return blockCodec{enc}, nil
+	},
+	"58": func(alphabet string) (codec, error) {
+		enc := base58.StdEncoding
+		if alphabet != "" {
+			enc = base58.NewEncoding(alphabet)
+		}
+		return radixCodec{enc}, nil
+	},
+	"62": func(alphabet string) (codec, error) {
+		enc := base62.StdEncoding
+		if alphabet != "" {
+			enc = base62.NewEncoding(alphabet)
+		}
+		return radixCodec{enc}, nil
+	},
+	"91": func(alphabet string) (codec, error) {
+		enc := base91.StdEncoding
+		if alphabet != "" {
+			enc = base91.NewEncoding(alphabet)
+		}
+		return radixCodec{enc}, nil
+	},
+	"92": func(alphabet string) (codec, error) {
+		enc := base92.StdEncoding
+		if alphabet != "" {
+			enc = base92.NewEncoding(alphabet)
+		}
+		return radixCodec{enc}, nil
+	},
+	"ascii85": func(alphabet string) (codec, error) {
+		enc := xascii85.StdEncoding
+		if alphabet != "" {
+			enc = xascii85.NewEncoding(alphabet)
+		}
+		return ascii85Codec{enc}, nil
+	},
+	"z85": func(alphabet string) (codec, error) {
+		enc := z85.PaddedEncoding
+		if alphabet != "" {
+			enc = z85.NewEncoding(alphabet).WithPadding()
+		}
+		return blockCodec{enc}, nil
+	},
+	"basE91": func(alphabet string) (codec, error) {
+		if alphabet != "" {
+			return nil, errNoAlphabet
+		}
+		return basE91Codec{}, nil
+	},
+}
+
+var errNoAlphabet = fmt.Errorf("this base does not support a custom --alphabet")
+
+// newCodec returns the codec of the given base.
+// NewEncoding panics on invalid alphabet: the panic is converted into an error.
+func newCodec(base, alphabet string) (c codec, err error) {
+	newFunc, ok := bases[base]
+	if !ok {
+		return nil, fmt.Errorf("unknown base %q, want one of: %s", base, baseNames())
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid alphabet: %v", r)
+		}
+	}()
+
+	return newFunc(alphabet)
+}
+
+func baseNames() string {
+	names := make([]string, 0, len(bases))
+	for name := range bases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,391 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Command basexx encodes and decodes data with any BaseXX encoding.
+// Its flags mirror the coreutils "base64" command,
+// so basexx can be used as a drop-in replacement in scripts.
+//
+// Usage
+//
+//	basexx [encode|decode] [OPTIONS] [FILE]
+//	basexx transcode --from BASE --to BASE [OPTIONS] [FILE]
+//
+// Encode a file in Base62, without line wrapping
+//
+//	basexx encode --base 62 -w 0 file.bin
+//
+// Decode from stdin (same as "basexx decode")
+//
+//	echo 2NEpo7TZRRrLZSi2U | basexx -d
+//
+// Use a custom alphabet
+//
+//	basexx --base 58 --alphabet ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz123456789 file.bin
+//
+// Migrate a file of Flickr Base58 identifiers (one per line) to Base62
+//
+//	basexx transcode --from 58 --from-alphabet 123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ --to 62 ids.txt
+//
+// The radix encodings (36, 58, 62, 91 and 92) are big-integer conversions:
+// the whole input is loaded in memory and the processing time is quadratic,
+// prefer ascii85 or basE91 for large files.
+//
+// Exit codes: 0 on success, 1 on input/output or decoding error,
+// and 2 on invalid command line usage.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+const defaultWrap = 76 // same as coreutils base64
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type options struct {
+	decode   bool
+	ignore   bool
+	help     bool
+	version  bool
+	wrap     int
+	base     string
+	alphabet string
+	output   string
+	input    string
+
+	// transcode subcommand
+	transcode  bool
+	to         string
+	toAlphabet string
+}
+
+func parse(args []string) (*options, error) {
+	var opt options
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "encode":
+			args = args[1:]
+		case "decode":
+			opt.decode = true
+			args = args[1:]
+		case "transcode":
+			opt.transcode = true
+			args = args[1:]
+		}
+	}
+
+	fs := flag.NewFlagSet("basexx", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if opt.transcode {
+		fs.StringVar(&opt.base, "f", "", "")
+		fs.StringVar(&opt.base, "from", "", "")
+		fs.StringVar(&opt.alphabet, "from-alphabet", "", "")
+		fs.StringVar(&opt.to, "t", "", "")
+		fs.StringVar(&opt.to, "to", "", "")
+		fs.StringVar(&opt.toAlphabet, "to-alphabet", "", "")
+	} else {
+		fs.BoolVar(&opt.decode, "d", opt.decode, "")
+		fs.BoolVar(&opt.decode, "decode", opt.decode, "")
+		fs.BoolVar(&opt.ignore, "i", false, "")
+		fs.BoolVar(&opt.ignore, "ignore-garbage", false, "")
+		fs.IntVar(&opt.wrap, "w", defaultWrap, "")
+		fs.IntVar(&opt.wrap, "wrap", defaultWrap, "")
+		fs.StringVar(&opt.base, "b", "58", "")
+		fs.StringVar(&opt.base, "base", "58", "")
+		fs.StringVar(&opt.alphabet, "a", "", "")
+		fs.StringVar(&opt.alphabet, "alphabet", "", "")
+	}
+	fs.StringVar(&opt.output, "o", "-", "")
+	fs.StringVar(&opt.output, "output", "-", "")
+	fs.BoolVar(&opt.help, "h", false, "")
+	fs.BoolVar(&opt.help, "help", false, "")
+	fs.BoolVar(&opt.version, "version", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch fs.NArg() {
+	case 0:
+		opt.input = "-"
+	case 1:
+		opt.input = fs.Arg(0)
+	default:
+		return nil, fmt.Errorf("extra operand %q", fs.Arg(1))
+	}
+
+	if opt.wrap < 0 {
+		return nil, fmt.Errorf("invalid wrap size: %d", opt.wrap)
+	}
+
+	if opt.transcode && !opt.help && !opt.version && (opt.base == "" || opt.to == "") {
+		return nil, fmt.Errorf("transcode requires both --from and --to")
+	}
+
+	return &opt, nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opt, err := parse(args)
+	if err != nil {
+		fmt.Fprintln(stderr, "basexx:", err)
+		fmt.Fprintln(stderr, "Try 'basexx --help' for more information.")
+		return exitUsage
+	}
+
+	switch {
+	case opt.help:
+		help(stdout)
+		return exitOK
+	case opt.version:
+		fmt.Fprintln(stdout, getVersion())
+		return exitOK
+	}
+
+	if opt.transcode {
+		return runTranscode(opt, stdin, stdout, stderr)
+	}
+
+	c, err := newCodec(opt.base, opt.alphabet)
+	if err != nil {
+		fmt.Fprintln(stderr, "basexx:", err)
+		return exitUsage
+	}
+
+	if err := convert(opt, c, stdin, stdout); err != nil {
+		fmt.Fprintln(stderr, "basexx:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func runTranscode(opt *options, stdin io.Reader, stdout, stderr io.Writer) int {
+	src, err := newRadix(opt.base, opt.alphabet)
+	if err != nil {
+		fmt.Fprintln(stderr, "basexx: --from:", err)
+		return exitUsage
+	}
+
+	dst, err := newRadix(opt.to, opt.toAlphabet)
+	if err != nil {
+		fmt.Fprintln(stderr, "basexx: --to:", err)
+		return exitUsage
+	}
+
+	err = withFiles(opt, stdin, stdout, func(in io.Reader, out io.Writer) error {
+		return transcode(src, dst, in, out)
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "basexx:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// newRadix is like newCodec but only accepts the radix encodings
+// because Transcode converts digits between two radixes.
+func newRadix(base, alphabet string) (encoding.Encoder, error) {
+	c, err := newCodec(base, alphabet)
+	if err != nil {
+		return nil, err
+	}
+	rc, ok := c.(radixCodec)
+	if !ok {
+		return nil, fmt.Errorf("base %q is not a radix encoding", base)
+	}
+	return rc.enc, nil
+}
+
+// transcode converts one value per line. Empty lines are kept as is.
+func transcode(src, dst encoding.Encoder, in io.Reader, out io.Writer) error {
+	r := bufio.NewReader(in)
+	w := bufio.NewWriter(out)
+
+	for line := 1; ; line++ {
+		s, err := r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if s == "" && err == io.EOF {
+			break
+		}
+
+		s = strings.TrimRight(s, "\r\n")
+		t, e := encoding.Transcode(src, dst, s)
+		if e != nil {
+			return fmt.Errorf("line %d: %w", line, e)
+		}
+
+		w.WriteString(t)
+		w.WriteByte('\n')
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return w.Flush()
+}
+
+// withFiles opens the input and output files (or uses stdin and stdout)
+// and passes them to f.
+func withFiles(opt *options, stdin io.Reader, stdout io.Writer, f func(in io.Reader, out io.Writer) error) (err error) {
+	in := stdin
+	if opt.input != "-" {
+		file, err := os.Open(opt.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	out := stdout
+	if opt.output != "-" {
+		file, err := os.Create(opt.output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if e := file.Close(); err == nil {
+				err = e
+			}
+		}()
+		out = file
+	}
+
+	return f(in, out)
+}
+
+func convert(opt *options, c codec, stdin io.Reader, stdout io.Writer) error {
+	return withFiles(opt, stdin, stdout, func(in io.Reader, out io.Writer) error {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+
+		var result []byte
+		if opt.decode {
+			result, err = decode(c, data, opt.ignore)
+			if err != nil {
+				return err
+			}
+		} else {
+			result = wrap(c.encode(data), opt.wrap)
+		}
+
+		_, err = out.Write(result)
+		return err
+	})
+}
+
+// decode skips the whitespaces (unless they belong to the alphabet),
+// and also all the other non-alphabet characters when ignoreGarbage is set.
+func decode(c codec, txt []byte, ignoreGarbage bool) ([]byte, error) {
+	filtered := txt[:0:0]
+	for i, b := range txt {
+		switch {
+		case c.valid(b):
+			filtered = append(filtered, b)
+		case ignoreGarbage || isSpace(b):
+			continue
+		default:
+			return nil, fmt.Errorf("invalid input: character %q at byte %d", b, i)
+		}
+	}
+
+	return c.decode(filtered)
+}
+
+func isSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+// wrap inserts a newline every width characters and terminates the last line.
+// A zero width disables the wrapping, only the final newline is appended.
+func wrap(txt []byte, width int) []byte {
+	if len(txt) == 0 {
+		return txt
+	}
+
+	if width == 0 || len(txt) <= width {
+		return append(txt, '\n')
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(txt) + len(txt)/width + 1)
+	for len(txt) > width {
+		buf.Write(txt[:width])
+		buf.WriteByte('\n')
+		txt = txt[width:]
+	}
+	buf.Write(txt)
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func getVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func help(w io.Writer) {
+	fmt.Fprintln(w, "basexx - Encode/decode data with any BaseXX encoding.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  basexx [encode|decode] [OPTIONS] [FILE]")
+	fmt.Fprintln(w, "  basexx transcode --from BASE --to BASE [OPTIONS] [FILE]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "With no FILE, or when FILE is -, read standard input.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Options:")
+	fmt.Fprintln(w, "  -b, --base BASE          Encoding, one of: "+baseNames()+" (default 58).")
+	fmt.Fprintln(w, "  -a, --alphabet CHARS     Custom encoding alphabet.")
+	fmt.Fprintln(w, "  -d, --decode             Decode data (same as the decode subcommand).")
+	fmt.Fprintln(w, "  -i, --ignore-garbage     When decoding, ignore non-alphabet characters.")
+	fmt.Fprintf(w, "  -w, --wrap COLS          Wrap encoded lines after COLS characters (default %d).\n", defaultWrap)
+	fmt.Fprintln(w, "                           Use 0 to disable line wrapping.")
+	fmt.Fprintln(w, "  -o, --output FILE        Write to FILE instead of standard output.")
+	fmt.Fprintln(w, "  -h, --help               Show this help.")
+	fmt.Fprintln(w, "      --version            Print version.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "When decoding, the whitespaces are ignored")
+	fmt.Fprintln(w, "unless they belong to the encoding alphabet.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Transcode options (one value per line, radix bases only):")
+	fmt.Fprintln(w, "  -f, --from BASE          Encoding of the input values.")
+	fmt.Fprintln(w, "      --from-alphabet CHARS  Custom alphabet of the input values.")
+	fmt.Fprintln(w, "  -t, --to BASE            Encoding of the output values.")
+	fmt.Fprintln(w, "      --to-alphabet CHARS  Custom alphabet of the output values.")
+	fmt.Fprintln(w, "  -o, --output FILE        Write to FILE instead of standard output.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit status:")
+	fmt.Fprintln(w, "  0 on success, 1 on input/output or decoding error, 2 on usage error.")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_run(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		stdin  string
+		stdout string
+		code   int
+	}{
+		{"encode-default", nil, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", "1FVk6iLh9oT6ivJ\n", exitOK},
+		{"encode-58", []string{"encode", "--base", "58"}, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", "1FVk6iLh9oT6ivJ\n", exitOK},
+		{"encode-wrap", []string{"encode", "-w", "5"}, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", "1FVk6\niLh9o\nT6ivJ\n", exitOK},
+		{"encode-empty", []string{"encode"}, "", "", exitOK},
+		{"encode-alphabet", []string{"-a", "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz123456789"}, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", "AQeuFsVrJxcFs5T\n", exitOK},
+		{"decode", []string{"decode"}, "1FVk6iLh9oT6ivJ\n", "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", exitOK},
+		{"decode-flag", []string{"-d"}, "1FVk6\niLh9o\r\nT6ivJ\n", "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", exitOK},
+		{"decode-garbage", []string{"-d"}, "1FVk6-iLh9oT6ivJ", "", exitError},
+		{"decode-ignore-garbage", []string{"-d", "-i"}, "1FVk6-iLh9oT6ivJ", "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xfe\xff", exitOK},
+		{"ascii85", []string{"-b", "ascii85"}, "Hello", "87cURDZ\n", exitOK},
+		{"ascii85-decode", []string{"decode", "-b", "ascii85"}, "87cU RDZ", "Hello", exitOK},
+		{"base32", []string{"-b", "32"}, "foobar", "MZXW6YTBOI======\n", exitOK},
+		{"base32-decode", []string{"-d", "-b", "32"}, "MZXW6YTB\nOI======\n", "foobar", exitOK},
+		{"crockford-decode", []string{"-d", "-b", "crockford"}, "h87n-o4hm\n", "\x8a\x0f\x50\x12\x34", exitOK},
+		{"zbase32", []string{"-b", "zbase32"}, "\xf0\xbf\xc7", "6n9hq\n", exitOK},
+		{"base36", []string{"-b", "36"}, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\xff", "0rwg9z1idsugqv3\n", exitOK},
+		{"transcode-36", []string{"transcode", "-f", "58", "-t", "36"}, "1FVk6iLh9oT6ivJ", "05ierqx6qq54n311b\n", exitOK},
+		{"base45", []string{"-b", "45"}, "Hello!!", "%69 VD92EX0\n", exitOK},
+		{"base45-decode", []string{"-d", "-b", "45"}, "%69 VD\n92EX0\n", "Hello!!", exitOK},
+		{"z85", []string{"-b", "z85"}, "\x86\x4F\xD2\x6F\xB5\x59\xF7\x5B", "HelloWorld\n", exitOK},
+		{"z85-decode", []string{"-d", "-b", "z85"}, "Hello\nWorld\n", "\x86\x4F\xD2\x6F\xB5\x59\xF7\x5B", exitOK},
+		{"basE91", []string{"--base=basE91"}, "1234567890", "QztEml0o[2;(A\n", exitOK},
+		{"basE91-decode", []string{"-d", "--base=basE91"}, "QztEml0o\n[2;(A\n", "1234567890", exitOK},
+		{"basE91-garbage", []string{"-d", "--base=basE91"}, "QztEml0o-[2;(A", "", exitError},
+		{"transcode", []string{"transcode", "--from", "58", "--to", "62"}, "1FVk6iLh9oT6ivJ\n\n1\r\n2NEpo7TZRRrLZSi2U", "065eoDiDgza96tz\n\n0\nT8dgcjRGkZ3aysdN\n", exitOK},
+		{"transcode-alphabet", []string{"transcode", "-f", "58", "--from-alphabet", "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz123456789", "-t", "58"}, "AQeuFsVrJxcFs5T", "1FVk6iLh9oT6ivJ\n", exitOK},
+		{"transcode-invalid", []string{"transcode", "-f", "58", "-t", "62"}, "1FVk\n0OIl\n", "", exitError},
+		{"transcode-missing-to", []string{"transcode", "-f", "58"}, "", "", exitUsage},
+		{"transcode-not-radix", []string{"transcode", "-f", "58", "-t", "ascii85"}, "", "", exitUsage},
+		{"help", []string{"--help"}, "", "", exitOK},
+		{"unknown-base", []string{"-b", "64"}, "", "", exitUsage},
+		{"bad-alphabet", []string{"-b", "62", "-a", "abc"}, "", "", exitUsage},
+		{"no-alphabet", []string{"-b", "basE91", "-a", "abc"}, "", "", exitUsage},
+		{"bad-flag", []string{"--bad"}, "", "", exitUsage},
+		{"extra-operand", []string{"a", "b"}, "", "", exitUsage},
+		{"missing-file", []string{"/does/not/exist"}, "", "", exitError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(c.args, strings.NewReader(c.stdin), &stdout, &stderr)
+			if code != c.code {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, c.code, stderr.String())
+			}
+			if c.name != "help" && stdout.String() != c.stdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), c.stdout)
+			}
+		})
+	}
+}
+
+func Test_run_files(t *testing.T) {
+	dir := t.TempDir()
+	bin := []byte("Garçon, un café très fort !")
+
+	in := filepath.Join(dir, "in.bin")
+	if err := os.WriteFile(in, bin, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for base := range bases {
+		enc := filepath.Join(dir, base+".txt")
+		if code := run([]string{"-b", base, "-w", "10", "-o", enc, in}, nil, nil, os.Stderr); code != exitOK {
+			t.Fatalf("base %s: encode exit code = %d", base, code)
+		}
+
+		dec := filepath.Join(dir, base+".bin")
+		if code := run([]string{"decode", "-b", base, "-o", dec, enc}, nil, nil, os.Stderr); code != exitOK {
+			t.Fatalf("base %s: decode exit code = %d", base, code)
+		}
+
+		got, err := os.ReadFile(dec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, bin) {
+			t.Errorf("base %s: decoded file differs from the original one: %q", base, got)
+		}
+	}
+}
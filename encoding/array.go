@@ -0,0 +1,147 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import "fmt"
+
+// LengthError reports decoded data having an unexpected length,
+// see DecodeInto and DecodeArray.
+type LengthError struct {
+	Base int // radix of the encoding: 58, 62, 85...
+	Got  int // length of the decoded data
+	Want int // expected length
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("Base%d: decoded %d bytes, want %d", e.Base, e.Got, e.Want)
+}
+
+// Decoder is implemented by the Encoding types of all the BaseXX packages.
+type Decoder interface {
+	DecodeInto(dst []byte, str string) error
+}
+
+// Array is the fixed-size byte arrays supported by DecodeArray:
+// UUIDs, RIPEMD-160 hashes, Ed25519 public keys, SHA-256 and SHA-512 hashes...
+type Array interface {
+	[16]byte | [20]byte | [32]byte | [64]byte
+}
+
+// DecodeArray decodes str into an array, such as a [32]byte public key.
+// The decoded data must have exactly the length of the array,
+// else the error is a *LengthError.
+// The array is the only allocation: it escapes to the heap
+// because it is decoded through the Decoder interface.
+//
+//	key, err := encoding.DecodeArray[[32]byte](base58.StdEncoding, str)
+func DecodeArray[A Array](dec Decoder, str string) (A, error) {
+	var a A
+	err := dec.DecodeInto(slice(&a), str)
+	return a, err
+}
+
+func slice[A Array](a *A) []byte {
+	switch p := any(a).(type) {
+	case *[16]byte:
+		return p[:]
+	case *[20]byte:
+		return p[:]
+	case *[32]byte:
+		return p[:]
+	case *[64]byte:
+		return p[:]
+	}
+	panic("unreachable")
+}
+
+// DecodeInto decodes str into dst, requiring the decoded data
+// to have exactly len(dst) bytes, else the error is a *LengthError.
+// The result is the same as DecodeString of the radix packages,
+// depending on the leading-zero mode (see WithLeadingZeros).
+// DecodeInto does not allocate memory when succeeding
+// (unless str contains ignored characters, see WithIgnore).
+// The content of dst is unspecified when an error occurs.
+func (enc *Encoding) DecodeInto(dst []byte, str string) error {
+	str = enc.Strip(str)
+
+	radix := uint(enc.Radix())
+	for i := 0; i < len(str); i++ {
+		if _, err := enc.digit(str[i]); err != nil {
+			return err
+		}
+	}
+
+	zcount := 0
+	for zcount < len(str) && enc.DecMap[str[zcount]] == 0 {
+		zcount++
+	}
+
+	value := dst
+	switch enc.zeros {
+	case BitcoinZeros:
+		// each leading zero digit is a leading zero byte
+		if zcount > len(dst) {
+			return enc.lengthError(dst, str)
+		}
+		for i := 0; i < zcount; i++ {
+			dst[i] = 0
+		}
+		value = dst[zcount:]
+	case LengthPreserving:
+		if len(str) != enc.FixedLen(len(dst)) {
+			return enc.lengthError(dst, str)
+		}
+	}
+
+	// big-endian multiply-add of the value digits directly into dst
+	for i := range value {
+		value[i] = 0
+	}
+	for i := zcount; i < len(str); i++ {
+		carry := uint(enc.DecMap[str[i]])
+		for j := len(value) - 1; j >= 0; j-- {
+			carry += uint(value[j]) * radix
+			value[j] = byte(carry)
+			carry >>= 8
+		}
+		if carry != 0 {
+			if enc.zeros == LengthPreserving {
+				return fmt.Errorf("Base%d: value does not fit in %d bytes", radix, len(dst))
+			}
+			return enc.lengthError(dst, str)
+		}
+	}
+
+	// the value must be minimal, except in LengthPreserving mode
+	if enc.zeros != LengthPreserving && len(value) > 0 && value[0] == 0 {
+		return enc.lengthError(dst, str)
+	}
+
+	return nil
+}
+
+// lengthError decodes str (the slow way) to report its decoded length.
+func (enc *Encoding) lengthError(dst []byte, str string) error {
+	digits := make([]byte, len(str))
+	for i := 0; i < len(str); i++ {
+		digits[i] = byte(enc.DecMap[str[i]])
+	}
+
+	var got int
+	switch enc.zeros {
+	case BitcoinZeros:
+		got = len(ConvertRadix(digits, enc.Radix(), 256))
+	case IntegerZeros:
+		got = len(TrimZeros(ConvertRadix(digits, enc.Radix(), 256)))
+	case LengthPreserving:
+		bin, err := enc.DecodeFixed(str)
+		if err != nil {
+			return err
+		}
+		got = len(bin)
+	}
+
+	return &LengthError{Base: enc.Radix(), Got: got, Want: len(dst)}
+}
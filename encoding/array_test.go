@@ -0,0 +1,167 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+type intoDecoder interface {
+	encoding.Decoder
+	EncodeToString(bin []byte) string
+	DecodeString(str string) ([]byte, error)
+}
+
+func TestDecodeInto(t *testing.T) {
+	var decoders []intoDecoder
+	for _, mode := range []encoding.LeadingZeros{encoding.BitcoinZeros, encoding.IntegerZeros, encoding.LengthPreserving} {
+		for _, enc := range zerosEncoders(mode) {
+			decoders = append(decoders, enc.(intoDecoder))
+		}
+	}
+	decoders = append(decoders,
+		z85.PaddedEncoding, xascii85.StdEncoding, xascii85.AdobeEncoding, base45.StdEncoding,
+		base32.StdEncoding, base32.CrockfordEncoding.WithCheck())
+
+	for i, dec := range decoders {
+		for _, bin := range zerosSamples() {
+			str := dec.EncodeToString(bin)
+			want, err := dec.DecodeString(str)
+			if err != nil {
+				t.Fatalf("#%d DecodeString(%q) error = %v", i, str, err)
+			}
+
+			for _, size := range []int{len(want) - 1, len(want), len(want) + 1} {
+				if size < 0 {
+					continue
+				}
+				dst := make([]byte, size)
+				err := dec.DecodeInto(dst, str)
+
+				if size == len(want) {
+					if err != nil || !bytes.Equal(dst, want) {
+						t.Fatalf("#%d DecodeInto(%q) = %x, %v, want %x", i, str, dst, err, want)
+					}
+					continue
+				}
+
+				var lenErr *encoding.LengthError
+				if !errors.As(err, &lenErr) || lenErr.Got != len(want) || lenErr.Want != size {
+					t.Fatalf("#%d DecodeInto(%q) into %d bytes: error = %v, want a LengthError got=%d", i, str, size, err, len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeInto_Errors(t *testing.T) {
+	dst := make([]byte, 4)
+	for _, str := range []string{"0OIl", "1111\xff"} {
+		if err := base58.StdEncoding.DecodeInto(dst, str); err == nil {
+			t.Errorf("DecodeInto(%q) want an error", str)
+		}
+	}
+}
+
+func TestDecodeArray(t *testing.T) {
+	hash := sha256.Sum256([]byte("hello"))
+	str := base58.StdEncoding.EncodeToString(hash[:])
+
+	got, err := encoding.DecodeArray[[32]byte](base58.StdEncoding, str)
+	if err != nil || got != hash {
+		t.Errorf("DecodeArray(%q) = %x, %v, want %x", str, got, err, hash)
+	}
+
+	_, err = encoding.DecodeArray[[20]byte](base58.StdEncoding, str)
+	var lenErr *encoding.LengthError
+	if !errors.As(err, &lenErr) || lenErr.Got != 32 || lenErr.Want != 20 {
+		t.Errorf("DecodeArray[[20]byte](%q) error = %v, want a LengthError", str, err)
+	}
+
+	var uuid [16]byte
+	rand.Read(uuid[:])
+	uuid[0] = 0 // leading zero
+	str = z85.StdEncoding.EncodeToString(uuid[:])
+	if got, err := encoding.DecodeArray[[16]byte](z85.StdEncoding, str); err != nil || got != uuid {
+		t.Errorf("DecodeArray(%q) = %x, %v, want %x", str, got, err, uuid)
+	}
+
+	var sig [64]byte
+	rand.Read(sig[:])
+	str = base62.StdEncoding.EncodeToString(sig[:])
+	if got, err := encoding.DecodeArray[[64]byte](base62.StdEncoding, str); err != nil || got != sig {
+		t.Errorf("DecodeArray(%q) = %x, %v, want %x", str, got, err, sig)
+	}
+}
+
+func TestDecodeInto_Allocs(t *testing.T) {
+	hash := sha256.Sum256([]byte("hello"))
+	str := base58.StdEncoding.EncodeToString(hash[:])
+	dst := make([]byte, 32)
+
+	if n := testing.AllocsPerRun(100, func() { _ = base58.StdEncoding.DecodeInto(dst, str) }); n > 0 {
+		t.Errorf("DecodeInto() allocates %v times, want none", n)
+	}
+}
+
+func TestDecodeInto_BlockAllocs(t *testing.T) {
+	key := sha256.Sum256([]byte("hello"))
+	dst := make([]byte, 32)
+
+	for _, dec := range []intoDecoder{
+		xascii85.StdEncoding, xascii85.AdobeEncoding, z85.PaddedEncoding,
+		base45.StdEncoding, base32.StdEncoding, base32.CrockfordEncoding.WithCheck(),
+	} {
+		str := dec.EncodeToString(key[:])
+		if err := dec.DecodeInto(dst, str); err != nil || !bytes.Equal(dst, key[:]) {
+			t.Fatalf("%T DecodeInto(%q) = %x, %v, want %x", dec, str, dst, err, key)
+		}
+		if n := testing.AllocsPerRun(100, func() { _ = dec.DecodeInto(dst, str) }); n > 0 {
+			t.Errorf("%T DecodeInto(%q) allocates %v times, want none", dec, str, n)
+		}
+	}
+}
+
+func TestDecodeArray_Allocs(t *testing.T) {
+	hash := sha256.Sum256([]byte("hello"))
+
+	for _, dec := range []intoDecoder{base58.StdEncoding, z85.StdEncoding, base32.StdEncoding} {
+		str := dec.EncodeToString(hash[:])
+		if n := testing.AllocsPerRun(100, func() { _, _ = encoding.DecodeArray[[32]byte](dec, str) }); n > 1 {
+			t.Errorf("%T DecodeArray(%q) allocates %v times, want only the array", dec, str, n)
+		}
+	}
+}
+
+type partialDecoder interface {
+	DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error)
+}
+
+func TestDecodePartial_NilDst(t *testing.T) {
+	hash := sha256.Sum256([]byte("hello"))
+
+	for _, dec := range []intoDecoder{
+		xascii85.StdEncoding, xascii85.AdobeEncoding, z85.StdEncoding,
+		base45.StdEncoding, base32.StdEncoding, base32.CrockfordEncoding.WithCheck(),
+	} {
+		src := []byte(dec.EncodeToString(hash[:]))
+		ndst, nsrc, err := dec.(partialDecoder).DecodePartial(nil, src, true)
+		if ndst != 0 || nsrc == len(src) || err != nil {
+			t.Errorf("%T DecodePartial(nil, %q) = %d, %d, %v, want no room in dst", dec, src, ndst, nsrc, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+// DecodeFunc decodes the whole str into dst, as the DecodePartial method
+// of the block encodings (base32, base45, xascii85 and z85)
+// with flush set to true: it stops before the end of str
+// only when dst has not enough room for the next block.
+// A nil dst only validates str: ndst counts the decoded bytes.
+type DecodeFunc func(dst []byte, str string) (ndst, nsrc int, err error)
+
+// DecodeBlocksInto decodes str into dst with decode,
+// requiring exactly len(dst) decoded bytes, else the error is a *LengthError.
+// This is the DecodeInto method of the block encodings:
+// str is decoded without being copied, so that DecodeBlocksInto
+// does not allocate memory when succeeding.
+// The content of dst is unspecified when an error occurs.
+func DecodeBlocksInto(decode DecodeFunc, radix int, dst []byte, str string) error {
+	n, nsrc, err := decode(dst, str)
+	switch {
+	case err != nil:
+		return err
+	case nsrc < len(str):
+		// dst is too short: count the decoded bytes of the whole str
+		n, _, err = decode(nil, str)
+		if err != nil {
+			return err
+		}
+	case n == len(dst):
+		return nil
+	}
+
+	return &LengthError{Base: radix, Got: n, Want: len(dst)}
+}
+
+// ValidBlocks reports whether str is valid for decode
+// and returns the length of the decoded data.
+// This is the Valid and ValidFor methods of the block encodings:
+// decode only counts the decoded bytes (nil dst),
+// so that ValidBlocks does not allocate memory.
+func ValidBlocks(decode DecodeFunc, str string) (n int, ok bool) {
+	n, nsrc, err := decode(nil, str)
+	return n, err == nil && nsrc == len(str)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+type bytesDecoder interface {
+	EncodeToString(bin []byte) string
+	DecodeString(str string) ([]byte, error)
+	DecodeBytes(src []byte) ([]byte, error)
+	Decode(dst, src []byte) (int, error)
+	DecodedLen(n int) int
+}
+
+func TestDecodeBytes(t *testing.T) {
+	decoders := []bytesDecoder{
+		base58.StdEncoding.WithGroups(4, "-"),
+		base62.StdEncoding.WithIgnore(" \n"),
+		z85.PaddedEncoding, xascii85.AdobeEncoding, base45.StdEncoding,
+		base32.StdEncoding, base32.CrockfordEncoding.WithCheck(),
+	}
+	for _, mode := range []encoding.LeadingZeros{encoding.BitcoinZeros, encoding.IntegerZeros, encoding.LengthPreserving} {
+		for _, enc := range zerosEncoders(mode) {
+			decoders = append(decoders, enc.(bytesDecoder))
+		}
+	}
+
+	r := rand.New(rand.NewSource(2))
+	for d, dec := range decoders {
+		for _, bin := range zerosSamples() {
+			for _, str := range mutations(dec.EncodeToString(bin), r) {
+				want, wantErr := dec.DecodeString(str)
+
+				got, err := dec.DecodeBytes([]byte(str))
+				if (err == nil) != (wantErr == nil) || !bytes.Equal(got, want) {
+					t.Fatalf("#%d DecodeBytes(%q) = %x, %v, want %x, %v", d, str, got, err, want, wantErr)
+				}
+
+				dst := make([]byte, dec.DecodedLen(len(str)))
+				n, err := dec.Decode(dst, []byte(str))
+				if (err == nil) != (wantErr == nil) || (err == nil && !bytes.Equal(dst[:n], want)) {
+					t.Fatalf("#%d Decode(%q) = %x, %v, want %x, %v", d, str, dst[:n], err, want, wantErr)
+				}
+			}
+		}
+	}
+}
+
+func TestDecode_ShortDst(t *testing.T) {
+	str := base58.StdEncoding.EncodeToString([]byte("hello"))
+	if n, err := base58.StdEncoding.Decode(make([]byte, 4), []byte(str)); err == nil {
+		t.Errorf("Decode(%q) into 4 bytes = %d, want an error", str, n)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+)
+
+// DecodeConstantTime decodes str, encoded by one of the radix encodings
+// (base36, base58, base62, base91 and base92), into exactly size bytes.
+// This is the result of DecodeString for a string encoding size bytes,
+// such as a token minted from size random bytes.
+//
+// Unlike DecodeString, the processing time only depends on len(str) and size,
+// not on the digits: the digits are converted by table lookups
+// without data-dependent branches, the leading zero digits are not skipped
+// and the arithmetic is done on a fixed number of limbs.
+// The validity of the digits is only checked at the end.
+// The ignored characters (see WithIgnore) are still skipped:
+// their positions are not supposed to be secret.
+//
+// It returns an error if enc is not a radix encoding in the BitcoinZeros mode,
+// if str contains an invalid digit or if its value does not fit in size bytes.
+func DecodeConstantTime(enc Encoder, str string, size int) ([]byte, error) {
+	if err := checkRadix(enc); err != nil {
+		return nil, err
+	}
+
+	alphabet := enc.Alphabet()
+	radix := uint64(alphabet.Radix())
+	str = alphabet.Strip(str)
+
+	if size < 0 {
+		return nil, fmt.Errorf("Base%d: negative size %d", radix, size)
+	}
+
+	// one more limb to detect the overflow of size bytes
+	limbs := make([]uint32, (size+3)/4+1)
+
+	var invalid byte
+	var overflow uint64
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		d := byte(alphabet.DecMap[c&0x7f])
+		invalid |= c>>7 | d>>7 // high-bit set on character or -1 in DecMap
+
+		carry := uint64(d & 0x7f)
+		for j := len(limbs) - 1; j >= 0; j-- {
+			t := uint64(limbs[j])*radix + carry
+			limbs[j] = uint32(t)
+			carry = t >> 32
+		}
+		overflow |= carry
+	}
+
+	bin := make([]byte, 4*len(limbs))
+	for j, limb := range limbs {
+		bin[4*j] = byte(limb >> 24)
+		bin[4*j+1] = byte(limb >> 16)
+		bin[4*j+2] = byte(limb >> 8)
+		bin[4*j+3] = byte(limb)
+	}
+
+	extra := len(bin) - size
+	var high byte
+	for _, b := range bin[:extra] {
+		high |= b
+	}
+
+	if invalid != 0 {
+		return nil, fmt.Errorf("Base%d: invalid digit", radix)
+	}
+	if overflow != 0 || high != 0 {
+		return nil, fmt.Errorf("Base%d: value does not fit in %d bytes", radix, size)
+	}
+
+	return bin[extra:], nil
+}
+
+// ConstantTimeEqual reports whether a and b encode the same data
+// with a radix encoding (base36, base58, base62, base91 and base92),
+// in a time that does not depend on the compared characters.
+// The aliases (see WithAliases) decode as their digits,
+// the ignored characters (see WithIgnore) are skipped,
+// the invalid characters are never equal.
+//
+// The lengths are not secret: a and b of different lengths,
+// once the ignored characters are removed, are not equal.
+//
+// It panics if enc is not a radix encoding in the BitcoinZeros mode:
+// the block encodings (base32, base45, xascii85 and z85) have shortcuts
+// and non-canonical forms, the other leading zero modes
+// decode different strings into the same data.
+func ConstantTimeEqual(enc Encoder, a, b string) bool {
+	if err := checkRadix(enc); err != nil {
+		log.Panic(err)
+	}
+
+	alphabet := enc.Alphabet()
+	a, b = alphabet.Strip(a), alphabet.Strip(b)
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	var diff, invalid byte
+	for i := 0; i < len(a); i++ {
+		ca, cb := a[i], b[i]
+		da := byte(alphabet.DecMap[ca&0x7f])
+		db := byte(alphabet.DecMap[cb&0x7f])
+		invalid |= ca>>7 | cb>>7 | da>>7 | db>>7
+		diff |= da ^ db
+	}
+
+	return subtle.ConstantTimeByteEq(diff|invalid, 0) == 1
+}
+
+// blockDecoder is implemented by the block encodings
+// (base32, base45, xascii85 and z85).
+type blockDecoder interface {
+	DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error)
+}
+
+// checkRadix returns an error if enc is not a radix encoding
+// in the BitcoinZeros mode, the only one supported in constant time.
+func checkRadix(enc Encoder) error {
+	alphabet := enc.Alphabet()
+	if _, ok := enc.(blockDecoder); ok {
+		return fmt.Errorf("Base%d: constant-time decoding does not support the block encodings", alphabet.Radix())
+	}
+	if mode := alphabet.LeadingZeros(); mode != BitcoinZeros {
+		return fmt.Errorf("Base%d: constant-time decoding does not support the %v mode", alphabet.Radix(), mode)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+func TestDecodeConstantTime(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		size := rand.Intn(40)
+		bin := make([]byte, size)
+		rand.Read(bin)
+		if i%5 == 0 && size > 2 {
+			bin[0], bin[1] = 0, 0 // leading zeros
+		}
+
+		for _, enc := range []interface {
+			encoding.Encoder
+			EncodeToString([]byte) string
+		}{base36.StdEncoding, base58.StdEncoding, base62.StdEncoding, base91.StdEncoding, base92.StdEncoding} {
+			str := enc.EncodeToString(bin)
+			got, err := encoding.DecodeConstantTime(enc, str, size)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("Base%d: DecodeConstantTime(%q, %d) = %x, %v, want %x",
+					enc.Alphabet().Radix(), str, size, got, err, bin)
+			}
+		}
+	}
+}
+
+func TestDecodeConstantTime_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		str  string
+		size int
+	}{
+		{"invalid", "1FVk0iLh9oT6ivJ", 12},
+		{"non-ascii", "1FVk\xe9iLh9oT6ivJ", 12},
+		{"overflow", "1FVk6iLh9oT6ivJ", 10},
+		{"negative", "1FVk6iLh9oT6ivJ", -1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got, err := encoding.DecodeConstantTime(base58.StdEncoding, c.str, c.size); err == nil {
+				t.Errorf("DecodeConstantTime(%q, %d) = %x, want an error", c.str, c.size, got)
+			}
+		})
+	}
+
+	got, err := encoding.DecodeConstantTime(base58.StdEncoding.WithGroups(5, "-"), "1FVk6-iLh9o-T6ivJ", 16)
+	if want := []byte{0, 0, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255}; err != nil || !bytes.Equal(got, want) {
+		t.Errorf("DecodeConstantTime() = %x, %v, want %x", got, err, want)
+	}
+}
+
+func TestConstantTimeEqual(t *testing.T) {
+	cases := []struct {
+		enc  encoding.Encoder
+		a, b string
+		want bool
+	}{
+		{base58.StdEncoding, "1FVk6iLh9oT6ivJ", "1FVk6iLh9oT6ivJ", true},
+		{base58.StdEncoding, "1FVk6iLh9oT6ivJ", "1FVk6iLh9oT6ivK", false},
+		{base58.StdEncoding, "1FVk6iLh9oT6ivJ", "1FVk6iLh9oT6iv", false},
+		{base58.StdEncoding, "", "", true},
+		{base58.StdEncoding, "1FVk0", "1FVk0", false}, // invalid digits are never equal
+		{base58.StdEncoding, "1FVk\xe9", "1FVk\xe9", false},
+		{base36.StdEncoding.CaseInsensitive(), "0rwg9z1idsugqv3", "0RWG9Z1IDSUGQV3", true},
+		{base58.StdEncoding.WithGroups(5, "-"), "1FVk6-iLh9o", "1FVk6iLh9o", true},
+	}
+
+	for _, c := range cases {
+		if got := encoding.ConstantTimeEqual(c.enc, c.a, c.b); got != c.want {
+			t.Errorf("ConstantTimeEqual(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// TestConstantTime_Unsupported checks the block encodings
+// and the other leading zero modes are rejected.
+func TestConstantTime_Unsupported(t *testing.T) {
+	for name, enc := range map[string]encoding.Encoder{
+		"base32":           base32.CrockfordEncoding,
+		"base45":           base45.StdEncoding,
+		"xascii85":         xascii85.StdEncoding,
+		"z85":              z85.PaddedEncoding,
+		"IntegerZeros":     base58.StdEncoding.WithLeadingZeros(encoding.IntegerZeros),
+		"sortable":         base62.SortableEncoding,
+		"LengthPreserving": base91.StdEncoding.WithLeadingZeros(encoding.LengthPreserving),
+	} {
+		if got, err := encoding.DecodeConstantTime(enc, "z", 4); err == nil {
+			t.Errorf("%s: DecodeConstantTime() = %x, want an error", name, got)
+		}
+
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected panic from ConstantTimeEqual() did not occur", name)
+				}
+			}()
+			encoding.ConstantTimeEqual(enc, "z", "z")
+		}()
+	}
+}
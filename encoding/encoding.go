@@ -12,6 +12,7 @@
 package encoding
 
 import (
+	"bytes"
 	"log"
 	"math"
 )
@@ -20,6 +21,15 @@ import (
 type Encoding struct {
 	EncChars []byte
 	DecMap   [128]int8
+
+	// output formatting and ignored input characters, see format.go
+	groupSize int
+	separator string
+	wrap      int
+	ignore    [128]bool
+
+	zeros    LeadingZeros // see zeros.go
+	sortable bool         // fixed-width output, see sortable.go
 }
 
 // NewEncoding creates a new alphabet mapping.
@@ -60,6 +70,59 @@ func NewEncoding(encoder string, base int) *Encoding {
 	return ret
 }
 
+// WithAliases returns a copy of the encoding where each key of aliases
+// is decoded as the digit given by its value,
+// e.g. {'O': '0', 'I': '1'} to accept common transcription mistakes.
+// The encoding is not affected: only the digits are produced.
+//
+// It panics if an alias is not ASCII, belongs to the alphabet,
+// or is already an alias of another digit,
+// or if a value is not a digit of the alphabet.
+func (enc *Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	base := len(enc.EncChars)
+	ret := *enc // DecMap is an array: the copy does not alter enc
+
+	for alias, digit := range aliases {
+		if alias > 127 {
+			log.Panicf("Base%d: alias %q is not an ASCII character", base, alias)
+		}
+		if bytes.IndexByte(enc.EncChars, alias) >= 0 {
+			log.Panicf("Base%d: alias %q collides with a digit of the alphabet", base, alias)
+		}
+		if enc.ignore[alias] {
+			log.Panicf("Base%d: alias %q is an ignored character", base, alias)
+		}
+		if bytes.IndexByte(enc.EncChars, digit) < 0 {
+			log.Panicf("Base%d: alias %q refers to %q that is not a digit of the alphabet", base, alias, digit)
+		}
+		if ret.DecMap[alias] != -1 && ret.DecMap[alias] != enc.DecMap[digit] {
+			log.Panicf("Base%d: alias %q is already the alias of %q", base, alias, enc.EncChars[ret.DecMap[alias]])
+		}
+		ret.DecMap[alias] = enc.DecMap[digit]
+	}
+
+	return &ret
+}
+
+// CaseInsensitive returns a copy of the encoding decoding the letters
+// in both lower and upper cases: the other case of each letter
+// of the alphabet becomes its alias (see WithAliases).
+//
+// It panics if the alphabet contains a letter in both cases,
+// as Base58 and Base62 do.
+func (enc *Encoding) CaseInsensitive() *Encoding {
+	aliases := make(map[byte]byte)
+	for _, c := range enc.EncChars {
+		switch {
+		case 'a' <= c && c <= 'z':
+			aliases[c-'a'+'A'] = c
+		case 'A' <= c && c <= 'Z':
+			aliases[c-'A'+'a'] = c
+		}
+	}
+	return enc.WithAliases(aliases)
+}
+
 // PanicIfBadApproximation exits when a BaseXX is not well configured.
 func PanicIfBadApproximation(base, a, b int) {
 	want := math.Log(256) / math.Log(float64(base))
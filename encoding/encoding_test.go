@@ -0,0 +1,65 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+func TestWithAliases(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789ABCDEF", 16)
+	aliased := enc.WithAliases(map[byte]byte{'O': '0', 'o': '0', 'I': '1'})
+
+	for c, want := range map[byte]int8{'0': 0, 'O': 0, 'o': 0, 'I': 1, 'F': 15, 'f': -1, 'G': -1} {
+		if got := aliased.DecMap[c]; got != want {
+			t.Errorf("DecMap[%q] = %d, want %d", c, got, want)
+		}
+	}
+
+	if enc.DecMap['O'] != -1 {
+		t.Errorf("WithAliases must not alter the original encoding")
+	}
+	if string(aliased.EncChars) != string(enc.EncChars) {
+		t.Errorf("WithAliases must not alter the encoding characters")
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789abcdef", 16).CaseInsensitive()
+
+	for c, want := range map[byte]int8{'a': 10, 'A': 10, 'F': 15, 'g': -1, 'G': -1, '9': 9} {
+		if got := enc.DecMap[c]; got != want {
+			t.Errorf("DecMap[%q] = %d, want %d", c, got, want)
+		}
+	}
+}
+
+func TestWithAliases_Panics(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789ABCDEF", 16)
+
+	cases := []struct {
+		name string
+		f    func()
+	}{
+		{"alias-is-digit", func() { enc.WithAliases(map[byte]byte{'A': '0'}) }},
+		{"not-a-digit", func() { enc.WithAliases(map[byte]byte{'O': 'Z'}) }},
+		{"non-ascii", func() { enc.WithAliases(map[byte]byte{200: '0'}) }},
+		{"already-alias", func() { enc.WithAliases(map[byte]byte{'O': '0'}).WithAliases(map[byte]byte{'O': '1'}) }},
+		{"both-cases", func() { encoding.NewEncoding("0123456789ABCDEa", 16).CaseInsensitive() }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic did not occur")
+				}
+			}()
+			c.f()
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+// Flag is a command-line parameter decoded by the Codec of F,
+// implementing flag.Value, flag.Getter and encoding.TextUnmarshaler.
+// When Size is not zero, the decoded value must have exactly Size bytes.
+// The Flag type of each package is a Flag using its StdEncoding:
+//
+//	key := base58.Flag{Size: 32}
+//	flag.Var(&key, "key", "Ed25519 public key (Base58)")
+//	flag.Parse()
+//	use(key.Value)
+type Flag[F Format] struct {
+	Value []byte // decoded bytes
+	Size  int    // required number of bytes, zero for any length
+}
+
+// Set decodes s, as required by flag.Value.
+// The error is a *LengthError when the decoded length is not Size.
+func (f *Flag[F]) Set(s string) error {
+	c := codec[F]()
+
+	bin, err := c.DecodeBytes([]byte(s))
+	if err != nil {
+		return err
+	}
+
+	if f.Size > 0 && len(bin) != f.Size {
+		base := 0
+		if enc, ok := c.(Encoder); ok {
+			base = enc.Alphabet().Radix()
+		}
+		return &LengthError{Base: base, Got: len(bin), Want: f.Size}
+	}
+
+	f.Value = bin
+	return nil
+}
+
+// String re-encodes the value, as required by flag.Value.
+func (f *Flag[F]) String() string {
+	if f == nil || f.Value == nil {
+		return ""
+	}
+	return codec[F]().EncodeToString(f.Value)
+}
+
+// Get returns the decoded bytes, as required by flag.Getter.
+func (f *Flag[F]) Get() any { return f.Value }
+
+// UnmarshalText implements encoding.TextUnmarshaler (see flag.TextVar).
+func (f *Flag[F]) UnmarshalText(text []byte) error { return f.Set(string(text)) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (f *Flag[F]) MarshalText() ([]byte, error) { return []byte(f.String()), nil }
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	basexx "github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+var _ flag.Getter = &base58.Flag{}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestFlag(t *testing.T) {
+	hash := sha256.Sum256([]byte("hello"))
+	key := base58.StdEncoding.EncodeToString(hash[:])
+	salt := z85.PaddedEncoding.EncodeToString([]byte("salt"))
+	flickr := base58.FlickrEncoding.EncodeToString([]byte("Hello World"))
+
+	fs := newFlagSet()
+	k := base58.Flag{Size: 32}
+	s := z85.Flag{}
+	c := basexx.Flag[flickrFormat]{}
+	fs.Var(&k, "key", "public key")
+	fs.Var(&s, "salt", "salt")
+	fs.Var(&c, "flickr", "Flickr Base58")
+
+	if err := fs.Parse([]string{"-key", key, "-salt=" + salt, "-flickr", flickr}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k.Value, hash[:]) || k.String() != key {
+		t.Errorf("-key = %x (%s), want %x", k.Value, &k, hash)
+	}
+	if string(s.Value) != "salt" || s.String() != salt {
+		t.Errorf("-salt = %q (%s), want \"salt\"", s.Value, &s)
+	}
+	if got := fs.Lookup("flickr").Value.(flag.Getter).Get(); !bytes.Equal(got.([]byte), []byte("Hello World")) {
+		t.Errorf("-flickr = %q", got)
+	}
+}
+
+func TestFlag_Errors(t *testing.T) {
+	short := base58.StdEncoding.EncodeToString(make([]byte, 31))
+
+	k := base58.Flag{Size: 32}
+	err := k.Set(short)
+	var lenErr *basexx.LengthError
+	if !errors.As(err, &lenErr) || lenErr.Got != 31 || lenErr.Want != 32 {
+		t.Errorf("Set(%q) error = %v, want a LengthError", short, err)
+	}
+
+	fs := newFlagSet()
+	fs.Var(&k, "key", "public key")
+	err = fs.Parse([]string{"-key", "0OIl"})
+	if err == nil || !strings.Contains(err.Error(), `invalid value "0OIl" for flag -key`) ||
+		!strings.Contains(err.Error(), "Base58") {
+		t.Errorf("Parse() error = %v", err)
+	}
+	if k.Value != nil {
+		t.Errorf("Set() must not change the value on error: %x", k.Value)
+	}
+}
+
+func TestFlag_Text(t *testing.T) {
+	var f base62.Flag
+	if err := f.UnmarshalText([]byte("0AB")); err != nil {
+		t.Fatal(err)
+	}
+	text, err := f.MarshalText()
+	if err != nil || string(text) != "0AB" {
+		t.Errorf("MarshalText() = %q, %v, want \"0AB\"", text, err)
+	}
+
+	var zero *base32.Flag
+	if zero.String() != "" {
+		t.Errorf("String() on nil = %q", zero.String())
+	}
+
+	// PrintDefaults calls String() on zero values
+	fs := newFlagSet()
+	fs.Var(&base32.Flag{Size: 10}, "id", "identifier")
+	fs.PrintDefaults()
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import "log"
+
+// WithGroups returns a copy of the encoding emitting the digits
+// in groups of size characters joined by separator,
+// e.g. WithGroups(4, "-") produces "XXXX-XXXX-XX".
+// The characters of separator are ignored when decoding (see WithIgnore).
+// A size of zero disables the grouping.
+//
+// It panics if size is negative
+// or if separator contains a digit or an alias.
+func (enc *Encoding) WithGroups(size int, separator string) *Encoding {
+	if size < 0 {
+		log.Panicf("Base%d: invalid group size %d", len(enc.EncChars), size)
+	}
+	ret := enc.WithIgnore(separator)
+	ret.groupSize = size
+	ret.separator = separator
+	return ret
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters (including the group separators),
+// as the coreutils base64 command does.
+// The line breaks "\n" and "\r" are ignored when decoding.
+// A width of zero disables the line wrapping.
+//
+// It panics if width is negative or if the line breaks are digits or aliases.
+func (enc *Encoding) WithWrap(width int) *Encoding {
+	if width < 0 {
+		log.Panicf("Base%d: invalid line width %d", len(enc.EncChars), width)
+	}
+	ret := enc.WithIgnore("\r\n")
+	ret.wrap = width
+	return ret
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding,
+// typically the whitespaces and the separators pasted with the data.
+//
+// It panics if a character is not ASCII, is a digit or an alias.
+func (enc *Encoding) WithIgnore(chars string) *Encoding {
+	ret := *enc
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c > 127 {
+			log.Panicf("Base%d: ignored character %q is not ASCII", len(enc.EncChars), c)
+		}
+		if enc.DecMap[c] != -1 {
+			log.Panicf("Base%d: ignored character %q is a digit or an alias", len(enc.EncChars), c)
+		}
+		ret.ignore[c] = true
+	}
+	return &ret
+}
+
+// Ignored reports whether the decoders skip the character c.
+func (enc *Encoding) Ignored(c byte) bool {
+	return c < 128 && enc.ignore[c]
+}
+
+// Strip removes the ignored characters from str.
+// str is returned as is when there is nothing to remove.
+func (enc *Encoding) Strip(str string) string {
+	return Strip(enc, str)
+}
+
+// Strip is the generic form of Encoding.Strip
+// for the decoders accepting both strings and byte slices:
+// str is returned as is (not copied) when there is nothing to remove.
+func Strip[T string | []byte](enc *Encoding, str T) T {
+	i := 0
+	for i < len(str) && !enc.Ignored(str[i]) {
+		i++
+	}
+	if i == len(str) {
+		return str
+	}
+
+	b := make([]byte, 0, len(str))
+	b = append(b, str[:i]...)
+	for ; i < len(str); i++ {
+		if !enc.Ignored(str[i]) {
+			b = append(b, str[i])
+		}
+	}
+	return T(b)
+}
+
+// FormattedLen returns the length of n digits
+// once formatted with the group separators and the line breaks.
+func (enc *Encoding) FormattedLen(n int) int {
+	if n > 0 && enc.groupSize > 0 {
+		n += (n - 1) / enc.groupSize * len(enc.separator)
+	}
+	if n > 0 && enc.wrap > 0 {
+		n += (n - 1) / enc.wrap
+	}
+	return n
+}
+
+// Formatted returns the digits with the group separators and the line breaks,
+// or digits itself when the encoding has no output formatting.
+func (enc *Encoding) Formatted(digits []byte) []byte {
+	size := enc.FormattedLen(len(digits))
+	if size == len(digits) {
+		return digits
+	}
+	out := make([]byte, size)
+	copy(out, digits)
+	return out[:enc.Format(out, len(digits))]
+}
+
+// Format inserts the group separators and the line breaks
+// into the n digits at the beginning of dst
+// and returns the formatted length, FormattedLen(n).
+// dst must have at least FormattedLen(n) bytes.
+func (enc *Encoding) Format(dst []byte, n int) int {
+	size := enc.FormattedLen(n)
+	if size == n {
+		return n
+	}
+
+	digits := make([]byte, n)
+	copy(digits, dst[:n])
+
+	// first pass: the groups, second pass: the lines
+	grouped := digits
+	if enc.groupSize > 0 {
+		grouped = make([]byte, 0, size)
+		for i, d := range digits {
+			if i > 0 && i%enc.groupSize == 0 {
+				grouped = append(grouped, enc.separator...)
+			}
+			grouped = append(grouped, d)
+		}
+	}
+
+	if enc.wrap == 0 {
+		return copy(dst, grouped)
+	}
+
+	j := 0
+	for i, c := range grouped {
+		if i > 0 && i%enc.wrap == 0 {
+			dst[j] = '\n'
+			j++
+		}
+		dst[j] = c
+		j++
+	}
+	return j
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+func TestFormat(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789", 10)
+
+	cases := []struct {
+		name   string
+		enc    *encoding.Encoding
+		digits string
+		want   string
+	}{
+		{"none", enc, "0123456789", "0123456789"},
+		{"groups", enc.WithGroups(4, "-"), "0123456789", "0123-4567-89"},
+		{"groups-exact", enc.WithGroups(5, " - "), "0123456789", "01234 - 56789"},
+		{"wrap", enc.WithWrap(4), "0123456789", "0123\n4567\n89"},
+		{"wrap-exact", enc.WithWrap(5), "0123456789", "01234\n56789"},
+		{"groups-wrap", enc.WithGroups(2, ".").WithWrap(6), "0123456789", "01.23.\n45.67.\n89"},
+		{"empty", enc.WithGroups(2, ".").WithWrap(6), "", ""},
+		{"one", enc.WithGroups(1, "."), "1", "1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if n := c.enc.FormattedLen(len(c.digits)); n != len(c.want) {
+				t.Errorf("FormattedLen(%d) = %d, want %d", len(c.digits), n, len(c.want))
+			}
+			if got := string(c.enc.Formatted([]byte(c.digits))); got != c.want {
+				t.Errorf("Formatted(%q) = %q, want %q", c.digits, got, c.want)
+			}
+			if got := c.enc.Strip(c.want); got != c.digits {
+				t.Errorf("Strip(%q) = %q, want %q", c.want, got, c.digits)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789", 10).WithIgnore(" \t\r\n-")
+
+	for str, want := range map[string]string{
+		"":                "",
+		"0123":            "0123",
+		" 01-23 \r\n45\t": "012345",
+		"--\n":            "",
+		"01x2":            "01x2", // the invalid characters are kept for the decoder errors
+	} {
+		if got := enc.Strip(str); got != want {
+			t.Errorf("Strip(%q) = %q, want %q", str, got, want)
+		}
+	}
+}
+
+func TestWithIgnore_Panics(t *testing.T) {
+	enc := encoding.NewEncoding("0123456789", 10)
+
+	cases := []struct {
+		name string
+		f    func()
+	}{
+		{"digit", func() { enc.WithIgnore(" 0") }},
+		{"alias", func() { enc.WithAliases(map[byte]byte{'O': '0'}).WithIgnore("O") }},
+		{"alias-of-ignored", func() { enc.WithIgnore("O").WithAliases(map[byte]byte{'O': '0'}) }},
+		{"non-ascii", func() { enc.WithIgnore("\xff") }},
+		{"separator-digit", func() { enc.WithGroups(4, "1") }},
+		{"negative-group", func() { enc.WithGroups(-1, "-") }},
+		{"negative-wrap", func() { enc.WithWrap(-1) }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic did not occur")
+				}
+			}()
+			c.f()
+		})
+	}
+}
+
+func TestTranscode_Format(t *testing.T) {
+	from := base58.StdEncoding.WithIgnore(" \n")
+	to := base62.StdEncoding.WithGroups(5, "-")
+
+	got, err := encoding.Transcode(from, to, "1FVk6 iLh9o\nT6ivJ")
+	if want := "065eo-DiDgz-a96tz"; err != nil || got != want {
+		t.Errorf("Transcode() = %q, %v, want %q", got, err, want)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"math/bits"
+)
+
+// EncodeUint64 encodes v into its radix digits, without leading zero digit,
+// as the radix encodings encode the minimal big-endian bytes of v
+// (the bytes without leading zero byte).
+// Zero has no byte: it is encoded as the empty string.
+// The only allocation is the returned string.
+// The sortable mode does not apply: use EncodeFixed for a fixed width.
+func (enc *Encoding) EncodeUint64(v uint64) string {
+	var digits [64]byte // the longest encoding is 64 binary digits
+
+	radix := uint64(enc.Radix())
+	i := len(digits)
+	for v > 0 {
+		i--
+		digits[i] = enc.EncChars[v%radix]
+		v /= radix
+	}
+
+	return string(enc.Formatted(digits[i:]))
+}
+
+// DecodeUint64 decodes the radix digits of an unsigned 64-bit integer.
+// The leading zero digits are accepted (they do not change the value)
+// and the empty string is zero, as encoded by EncodeUint64.
+// DecodeUint64 returns an error if the value exceeds 64 bits
+// and does not allocate memory (unless str contains ignored characters).
+func (enc *Encoding) DecodeUint64(str string) (uint64, error) {
+	str = enc.Strip(str)
+
+	radix := uint64(enc.Radix())
+	var v uint64
+	for i := 0; i < len(str); i++ {
+		d, err := enc.digit(str[i])
+		if err != nil {
+			return 0, err
+		}
+
+		hi, lo := bits.Mul64(v, radix)
+		var carry uint64
+		v, carry = bits.Add64(lo, d, 0)
+		if hi != 0 || carry != 0 {
+			return 0, fmt.Errorf("Base%d: integer %q exceeds 64 bits", enc.Radix(), str)
+		}
+	}
+
+	return v, nil
+}
+
+// EncodeBigInt encodes x into its radix digits, without leading zero digit,
+// as the radix encodings encode x.Bytes(). Zero is encoded as the empty string
+// (x.Bytes() is empty), see EncodeUint64.
+// It panics if x is negative.
+func (enc *Encoding) EncodeBigInt(x *big.Int) string {
+	if x.Sign() < 0 {
+		log.Panicf("Base%d: cannot encode the negative integer %v", enc.Radix(), x)
+	}
+	if x.IsUint64() {
+		return enc.EncodeUint64(x.Uint64())
+	}
+
+	radix := uint64(enc.Radix())
+	chunk, width := enc.bigChunk()
+
+	// convert chunk by chunk of width digits, from the least significant
+	digits := make([]byte, 0, x.BitLen()/2+width)
+	q := new(big.Int).Set(x)
+	r := new(big.Int)
+	for q.Sign() > 0 {
+		q.QuoRem(q, chunk, r)
+		v := r.Uint64()
+		for i := 0; i < width; i++ {
+			digits = append(digits, enc.EncChars[v%radix])
+			v /= radix
+		}
+	}
+
+	// trim the leading zero digits (at the end) and reverse
+	for digits[len(digits)-1] == enc.EncChars[0] {
+		digits = digits[:len(digits)-1]
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+
+	return string(enc.Formatted(digits))
+}
+
+// DecodeBigInt decodes the radix digits of an arbitrary large integer.
+// The leading zero digits are accepted (they do not change the value)
+// and the empty string is zero, as encoded by EncodeBigInt.
+func (enc *Encoding) DecodeBigInt(str string) (*big.Int, error) {
+	str = enc.Strip(str)
+
+	radix := uint64(enc.Radix())
+	chunk, width := enc.bigChunk()
+
+	// convert chunk by chunk of width digits, from the most significant
+	x := new(big.Int)
+	w := new(big.Int)
+	for len(str) > 0 {
+		n := len(str) % width
+		if n == 0 {
+			n = width
+		}
+
+		var v, pow uint64 = 0, 1
+		for i := 0; i < n; i++ {
+			d, err := enc.digit(str[i])
+			if err != nil {
+				return nil, err
+			}
+			v = v*radix + d
+			pow *= radix
+		}
+		str = str[n:]
+
+		if n == width {
+			x.Mul(x, chunk)
+		} else {
+			x.Mul(x, w.SetUint64(pow))
+		}
+		x.Add(x, w.SetUint64(v))
+	}
+
+	return x, nil
+}
+
+// bigChunk returns radix^width, the largest power of the radix fitting in 64 bits.
+func (enc *Encoding) bigChunk() (chunk *big.Int, width int) {
+	radix := uint64(enc.Radix())
+	pow := radix
+	width = 1
+	for {
+		hi, lo := bits.Mul64(pow, radix)
+		if hi != 0 {
+			break
+		}
+		pow = lo
+		width++
+	}
+	return new(big.Int).SetUint64(pow), width
+}
+
+func (enc *Encoding) digit(c byte) (uint64, error) {
+	if c > 127 {
+		return 0, fmt.Errorf("Base%d: high-bit set on invalid digit", enc.Radix())
+	}
+	if enc.DecMap[c] == -1 {
+		return 0, fmt.Errorf("Base%d: invalid digit %q", enc.Radix(), c)
+	}
+	return uint64(enc.DecMap[c]), nil
+}
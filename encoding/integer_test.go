@@ -0,0 +1,176 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+type integerEncoder interface {
+	sortable
+	EncodeUint64(v uint64) string
+	DecodeUint64(str string) (uint64, error)
+	EncodeBigInt(x *big.Int) string
+	DecodeBigInt(str string) (*big.Int, error)
+}
+
+var integerEncoders = []integerEncoder{
+	base36.StdEncoding,
+	base58.StdEncoding,
+	base62.StdEncoding,
+	base91.StdEncoding,
+	base92.StdEncoding,
+}
+
+// minimal returns the big-endian bytes of v without leading zero
+// (no byte for zero).
+func minimal(bin []byte) []byte {
+	for len(bin) > 0 && bin[0] == 0 {
+		bin = bin[1:]
+	}
+	return bin
+}
+
+func TestUint64(t *testing.T) {
+	values := []uint64{0, 1, 57, 58, 61, 62, 255, 256, math.MaxUint32, math.MaxUint64 - 1, math.MaxUint64}
+	for i := 0; i < 200; i++ {
+		values = append(values, rand.Uint64()>>(i%64))
+	}
+
+	for _, enc := range integerEncoders {
+		radix := enc.Alphabet().Radix()
+
+		for _, v := range values {
+			var bin [8]byte
+			binary.BigEndian.PutUint64(bin[:], v)
+			want := enc.EncodeToString(minimal(bin[:]))
+
+			str := enc.EncodeUint64(v)
+			if str != want {
+				t.Fatalf("Base%d: EncodeUint64(%d) = %q, want %q", radix, v, str, want)
+			}
+			got, err := enc.DecodeUint64(str)
+			if err != nil || got != v {
+				t.Fatalf("Base%d: DecodeUint64(%q) = %d, %v, want %d", radix, str, got, err, v)
+			}
+		}
+
+		// leading zero digits
+		zero := string(enc.Alphabet().EncChars[0])
+		if got, err := enc.DecodeUint64(zero + zero + enc.EncodeUint64(42)); err != nil || got != 42 {
+			t.Errorf("Base%d: DecodeUint64() = %d, %v, want 42", radix, got, err)
+		}
+
+		// overflow: MaxUint64 + 1
+		str := enc.EncodeBigInt(new(big.Int).Lsh(big.NewInt(1), 64))
+		if got, err := enc.DecodeUint64(str); err == nil {
+			t.Errorf("Base%d: DecodeUint64(%q) = %d, want an overflow error", radix, str, got)
+		}
+	}
+}
+
+func TestUint64_Errors(t *testing.T) {
+	enc := base58.StdEncoding
+
+	for _, str := range []string{"0", "1l", "\xff", "zzzzzzzzzzzzz"} {
+		if got, err := enc.DecodeUint64(str); err == nil {
+			t.Errorf("DecodeUint64(%q) = %d, want an error", str, got)
+		}
+		if got, err := enc.DecodeBigInt(str); err == nil && str != "zzzzzzzzzzzzz" {
+			t.Errorf("DecodeBigInt(%q) = %v, want an error", str, got)
+		}
+	}
+}
+
+// TestZero checks zero is the empty string, as the encoding of no byte,
+// whatever the leading zeros mode.
+func TestZero(t *testing.T) {
+	for _, enc := range integerEncoders {
+		radix := enc.Alphabet().Radix()
+
+		for _, mode := range []encoding.LeadingZeros{encoding.BitcoinZeros, encoding.IntegerZeros, encoding.LengthPreserving} {
+			alphabet := enc.Alphabet().WithLeadingZeros(mode)
+			if str := alphabet.EncodeUint64(0); str != "" {
+				t.Errorf("Base%d %v: EncodeUint64(0) = %q, want empty", radix, mode, str)
+			}
+			if str := alphabet.EncodeBigInt(new(big.Int)); str != "" {
+				t.Errorf("Base%d %v: EncodeBigInt(0) = %q, want empty", radix, mode, str)
+			}
+		}
+
+		if str := enc.EncodeToString(nil); str != "" {
+			t.Errorf("Base%d: EncodeToString(nil) = %q, want empty", radix, str)
+		}
+
+		zero := string(enc.Alphabet().EncChars[0])
+		for _, str := range []string{"", zero, zero + zero} {
+			if v, err := enc.DecodeUint64(str); err != nil || v != 0 {
+				t.Errorf("Base%d: DecodeUint64(%q) = %d, %v, want 0", radix, str, v, err)
+			}
+			if x, err := enc.DecodeBigInt(str); err != nil || x.Sign() != 0 {
+				t.Errorf("Base%d: DecodeBigInt(%q) = %v, %v, want 0", radix, str, x, err)
+			}
+		}
+	}
+}
+
+func TestUint64_Allocs(t *testing.T) {
+	enc := base62.StdEncoding
+	str := enc.EncodeUint64(math.MaxUint64)
+
+	if n := testing.AllocsPerRun(100, func() { _ = enc.EncodeUint64(math.MaxUint64) }); n > 1 {
+		t.Errorf("EncodeUint64() allocates %v times, want only the returned string", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _, _ = enc.DecodeUint64(str) }); n > 0 {
+		t.Errorf("DecodeUint64() allocates %v times, want none", n)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).SetUint64(math.MaxUint64)}
+	for _, bits := range []uint{64, 65, 127, 128, 255, 256, 1000} {
+		values = append(values,
+			new(big.Int).Lsh(big.NewInt(1), bits),
+			new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1)),
+			new(big.Int).Rand(rand.New(rand.NewSource(int64(bits))), new(big.Int).Lsh(big.NewInt(1), bits)))
+	}
+
+	for _, enc := range integerEncoders {
+		radix := enc.Alphabet().Radix()
+
+		for _, x := range values {
+			want := enc.EncodeToString(x.Bytes())
+
+			str := enc.EncodeBigInt(x)
+			if str != want {
+				t.Fatalf("Base%d: EncodeBigInt(%v) = %q, want %q", radix, x, str, want)
+			}
+			got, err := enc.DecodeBigInt(str)
+			if err != nil || got.Cmp(x) != 0 {
+				t.Fatalf("Base%d: DecodeBigInt(%q) = %v, %v, want %v", radix, str, got, err, x)
+			}
+		}
+	}
+}
+
+func TestBigInt_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on negative integer did not occur")
+		}
+	}()
+	base62.StdEncoding.EncodeBigInt(big.NewInt(-1))
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2017-2020 Denis Subbotin, Philip Schlump,
+//                         Nika Jones, Steven Allen, MoonFruit
+// Copyright (c) 2022      Teal.Finance contributors
+//
+// This file is a modified copy from https://github.com/mr-tron/base58
+// The source code has been adapted to support any pair of radixes.
+// This file is now part of BaseXX under the terms of the MIT License.
+// SPDX-License-Identifier: MIT
+// See the LICENSE file or https://opensource.org/licenses/MIT
+
+package encoding
+
+import (
+	"log"
+	"math"
+	"math/bits"
+)
+
+// ConvertRadix converts the big-endian digits from fromBase to toBase.
+// This is the digit conversion used by the radix packages
+// (base36, base58, base62, base91 and base92) independently of any alphabet:
+// Encode is ConvertRadix(bin, 256, Radix) and Decode is ConvertRadix(digits, Radix, 256).
+//
+// Each leading zero digit is converted to one leading zero digit
+// (the Bitcoin Base58 rule), the other leading zeros are removed.
+//
+// It panics if a base is not in the range [2, 256]
+// or if a digit is not lower than fromBase.
+func ConvertRadix(digits []byte, fromBase, toBase int) []byte {
+	checkBase(fromBase, 256)
+	checkBase(toBase, 256)
+	for _, d := range digits {
+		if int(d) >= fromBase {
+			log.Panicf("ConvertRadix: digit %d is out of base %d", d, fromBase)
+		}
+	}
+
+	zcount := 0
+	for zcount < len(digits) && digits[zcount] == 0 {
+		zcount++
+	}
+
+	if toBase == 256 {
+		return toBytes(digits, zcount, fromBase)
+	}
+
+	size := zcount + maxDigits(len(digits)-zcount, fromBase, toBase)
+
+	out := make([]byte, size)
+
+	// the division by a variable base is slow: divide the 32-bit carry
+	// by a multiplication with the 64-bit reciprocal (Lemire's fastdiv)
+	reciprocal := math.MaxUint64/uint64(toBase) + 1
+
+	var i, high int
+	var carry, quo uint64
+
+	high = size - 1
+	for _, d := range digits[zcount:] {
+		i = size - 1
+		for carry = uint64(d); i > high || carry != 0; i-- {
+			carry += uint64(fromBase) * uint64(out[i])
+			quo, _ = bits.Mul64(reciprocal, carry)
+			out[i] = byte(carry - quo*uint64(toBase))
+			carry = quo
+		}
+		high = i
+	}
+
+	// Determine the additional "zero-gap" in the buffer (aside from zcount)
+	for i = zcount; i < size && out[i] == 0; i++ {
+	}
+
+	return out[i-zcount:]
+}
+
+// toBytes is the 32-bit algorithm of DecodeString:
+// the digits are accumulated in 32-bit limbs, then split into bytes.
+func toBytes(digits []byte, zcount, fromBase int) []byte {
+	// a digit is at most one byte: n digits fit in n bytes
+	outi := make([]uint32, (len(digits)-zcount+3)/4)
+
+	var t, c uint64
+
+	for _, d := range digits[zcount:] {
+		c = uint64(d)
+
+		for j := len(outi) - 1; j >= 0; j-- {
+			t = uint64(outi[j])*uint64(fromBase) + c
+			c = t >> 32
+			outi[j] = uint32(t & 0xffffffff)
+		}
+	}
+
+	binu := make([]byte, zcount+4*len(outi))
+	for j, limb := range outi {
+		k := zcount + 4*j
+		binu[k] = byte(limb >> 24)
+		binu[k+1] = byte(limb >> 16)
+		binu[k+2] = byte(limb >> 8)
+		binu[k+3] = byte(limb)
+	}
+
+	// find the most significant byte post-decode, if any
+	for msb := zcount; msb < len(binu); msb++ {
+		if binu[msb] > 0 {
+			return binu[msb-zcount:]
+		}
+	}
+
+	// it's all zeroes
+	return binu[:zcount]
+}
+
+// ConvertRadix16 is the same as ConvertRadix
+// for bases up to 65536 stored in uint16 digits.
+//
+// It panics if a base is not in the range [2, 65536]
+// or if a digit is not lower than fromBase.
+func ConvertRadix16(digits []uint16, fromBase, toBase int) []uint16 {
+	checkBase(fromBase, 65536)
+	checkBase(toBase, 65536)
+	for _, d := range digits {
+		if int(d) >= fromBase {
+			log.Panicf("ConvertRadix16: digit %d is out of base %d", d, fromBase)
+		}
+	}
+
+	size := len(digits)
+
+	zcount := 0
+	for zcount < size && digits[zcount] == 0 {
+		zcount++
+	}
+
+	size = zcount + maxDigits(size-zcount, fromBase, toBase)
+
+	out := make([]uint16, size)
+
+	var i, high int
+	var carry uint64
+
+	high = size - 1
+	for _, d := range digits[zcount:] {
+		i = size - 1
+		for carry = uint64(d); i > high || carry != 0; i-- {
+			carry += uint64(fromBase) * uint64(out[i])
+			out[i] = uint16(carry % uint64(toBase))
+			carry /= uint64(toBase)
+		}
+		high = i
+	}
+
+	// Determine the additional "zero-gap" in the buffer (aside from zcount)
+	for i = zcount; i < size && out[i] == 0; i++ {
+	}
+
+	return out[i-zcount:]
+}
+
+func checkBase(base, max int) {
+	if base < 2 || base > max {
+		log.Panicf("ConvertRadix: base %d is out of the range [2, %d]", base, max)
+	}
+}
+
+// maxDigits returns the maximum number of toBase digits
+// required to represent n fromBase digits.
+func maxDigits(n, fromBase, toBase int) int {
+	ratio := math.Log(float64(fromBase)) / math.Log(float64(toBase))
+	// +1 because of the truncation, +1 to absorb the floating point rounding
+	return int(float64(n)*ratio) + 2
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+// bigDigits returns the minimal big-endian digits of n in the given base.
+func bigDigits(n *big.Int, base int) []uint16 {
+	var digits []uint16
+	b := big.NewInt(int64(base))
+	n = new(big.Int).Set(n)
+	m := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, b, m)
+		digits = append([]uint16{uint16(m.Int64())}, digits...)
+	}
+	return digits
+}
+
+func randDigits(n, base int) []uint16 {
+	digits := make([]uint16, n)
+	for i := range digits {
+		digits[i] = uint16(rand.Intn(base))
+	}
+	return digits
+}
+
+func bigValue(digits []uint16, base int) *big.Int {
+	n := new(big.Int)
+	b := big.NewInt(int64(base))
+	for _, d := range digits {
+		n.Mul(n, b)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	return n
+}
+
+func TestConvertRadix(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		from := 2 + rand.Intn(255)
+		to := 2 + rand.Intn(255)
+		if i%4 == 0 {
+			to = 256
+		}
+
+		digits16 := randDigits(rand.Intn(40), from)
+		zeros := rand.Intn(3)
+		digits16 = append(make([]uint16, zeros), digits16...)
+
+		digits := make([]byte, len(digits16))
+		for j, d := range digits16 {
+			digits[j] = byte(d)
+		}
+
+		got := encoding.ConvertRadix(digits, from, to)
+
+		// leading zeros of the input
+		zcount := 0
+		for zcount < len(digits) && digits[zcount] == 0 {
+			zcount++
+		}
+		want16 := append(make([]uint16, zcount), bigDigits(bigValue(digits16, from), to)...)
+		want := make([]byte, len(want16))
+		for j, d := range want16 {
+			want[j] = byte(d)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Fatalf("ConvertRadix(%v, %d, %d) = %v, want %v", digits, from, to, got, want)
+		}
+
+		got16 := encoding.ConvertRadix16(digits16, from, to)
+		if !equal16(got16, want16) {
+			t.Fatalf("ConvertRadix16(%v, %d, %d) = %v, want %v", digits16, from, to, got16, want16)
+		}
+	}
+}
+
+func TestConvertRadix_SameAsEncode(t *testing.T) {
+	for _, bin := range samples {
+		digits := encoding.ConvertRadix(bin, 256, base58.Radix)
+		for i, d := range digits {
+			digits[i] = base58.StdEncoding.EncChars[d]
+		}
+		if want := base58.StdEncoding.EncodeToString(bin); string(digits) != want {
+			t.Errorf("ConvertRadix(%v) = %q, want %q", bin, digits, want)
+		}
+
+		back := encoding.ConvertRadix(encoding.ConvertRadix(bin, 256, base58.Radix), base58.Radix, 256)
+		if !bytes.Equal(back, bin) && len(bin) > 0 {
+			t.Errorf("round-trip of %v = %v", bin, back)
+		}
+	}
+}
+
+func TestConvertRadix16(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		from := 2 + rand.Intn(65535)
+		to := 2 + rand.Intn(65535)
+
+		digits := append(make([]uint16, rand.Intn(3)), randDigits(rand.Intn(30), from)...)
+
+		got := encoding.ConvertRadix16(encoding.ConvertRadix16(digits, from, to), to, from)
+
+		zcount := 0
+		for zcount < len(digits) && digits[zcount] == 0 {
+			zcount++
+		}
+		want := append(make([]uint16, zcount), bigDigits(bigValue(digits, from), from)...)
+
+		if !equal16(got, want) {
+			t.Fatalf("round-trip %d->%d of %v = %v, want %v", from, to, digits, got, want)
+		}
+	}
+}
+
+func TestConvertRadix_Panics(t *testing.T) {
+	cases := []struct {
+		name     string
+		digits   []byte
+		from, to int
+	}{
+		{"from-too-small", []byte{0}, 1, 10},
+		{"to-too-large", []byte{0}, 10, 257},
+		{"digit-out-of-base", []byte{1, 10}, 10, 16},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic did not occur")
+				}
+			}()
+			encoding.ConvertRadix(c.digits, c.from, c.to)
+		})
+	}
+}
+
+func equal16(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// Sortable returns a copy of the encoding in sortable mode:
+// the encoded strings sort in the same order as the binary data
+// having the same length, as required by the sortable identifiers.
+//
+// In sortable mode, the radix encodings emit a fixed-width output,
+// FixedLen(n) digits for n bytes, left-padded with the zero digit
+// (the LengthPreserving leading-zero mode).
+//
+// It panics if the alphabet is not in ascending byte order.
+func (enc *Encoding) Sortable() *Encoding {
+	for i := 1; i < len(enc.EncChars); i++ {
+		if enc.EncChars[i-1] >= enc.EncChars[i] {
+			log.Panicf("Base%d: sortable alphabet must be in ascending order, but %q is before %q",
+				len(enc.EncChars), enc.EncChars[i-1], enc.EncChars[i])
+		}
+	}
+	ret := *enc
+	ret.zeros = LengthPreserving
+	ret.sortable = true
+	return &ret
+}
+
+// IsSortable reports whether the encoding is in sortable mode.
+func (enc *Encoding) IsSortable() bool { return enc.sortable }
+
+// FixedLen returns the number of digits required to encode
+// any value of n bytes: the smallest w such as radix^w >= 256^n.
+func (enc *Encoding) FixedLen(n int) int {
+	radix := enc.Radix()
+	if radix&(radix-1) == 0 { // power of two: exact integer arithmetic
+		b := bits.TrailingZeros(uint(radix))
+		return (8*n + b - 1) / b
+	}
+	// log2(radix) is irrational: 8n/log2(radix) is never an integer
+	return int(math.Ceil(float64(8*n) / math.Log2(float64(radix))))
+}
+
+// EncodeFixed encodes bin into FixedLen(len(bin)) characters,
+// left-padded with the zero digit.
+// This is the encoding of the radix packages in sortable mode.
+func (enc *Encoding) EncodeFixed(bin []byte) []byte {
+	zcount := 0
+	for zcount < len(bin) && bin[zcount] == 0 {
+		zcount++
+	}
+
+	// no leading zero: the digits are minimal
+	digits := ConvertRadix(bin[zcount:], 256, enc.Radix())
+
+	out := make([]byte, enc.FixedLen(len(bin)))
+	pad := len(out) - len(digits)
+	for i := range out[:pad] {
+		out[i] = enc.EncChars[0]
+	}
+	for i, d := range digits {
+		out[pad+i] = enc.EncChars[d]
+	}
+	return out
+}
+
+// DecodeFixed decodes str produced by EncodeFixed.
+// It returns an error on invalid digit,
+// or if the length of str is not a FixedLen value,
+// or if the value does not fit in the corresponding number of bytes.
+func (enc *Encoding) DecodeFixed(str string) ([]byte, error) {
+	return DecodeFixed(enc, str)
+}
+
+// DecodeFixed is the generic form of Encoding.DecodeFixed
+// for the decoders accepting both strings and byte slices.
+func DecodeFixed[T string | []byte](enc *Encoding, str T) ([]byte, error) {
+	radix := enc.Radix()
+
+	n := enc.fixedBytes(len(str))
+	if n < 0 {
+		return nil, fmt.Errorf("Base%d: invalid sortable length %d", radix, len(str))
+	}
+
+	digits := make([]byte, len(str))
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c > 127 {
+			return nil, fmt.Errorf("Base%d: high-bit set on invalid digit", radix)
+		}
+		if enc.DecMap[c] == -1 {
+			return nil, fmt.Errorf("Base%d: invalid digit %q", radix, c)
+		}
+		digits[i] = byte(enc.DecMap[c])
+	}
+
+	zcount := 0
+	for zcount < len(digits) && digits[zcount] == 0 {
+		zcount++
+	}
+
+	value := ConvertRadix(digits[zcount:], radix, 256)
+	if len(value) > n {
+		return nil, fmt.Errorf("Base%d: value does not fit in %d bytes", radix, n)
+	}
+
+	bin := make([]byte, n)
+	copy(bin[n-len(value):], value)
+	return bin, nil
+}
+
+// fixedBytes returns the number of bytes n such as FixedLen(n) == digits,
+// or -1 if digits is not a FixedLen value.
+func (enc *Encoding) fixedBytes(digits int) int {
+	// the largest n such as FixedLen(n) <= digits
+	n := int(float64(digits) * math.Log2(float64(enc.Radix())) / 8)
+	for n > 0 && enc.FixedLen(n) > digits {
+		n--
+	}
+	for enc.FixedLen(n+1) <= digits {
+		n++
+	}
+	if enc.FixedLen(n) != digits {
+		return -1
+	}
+	return n
+}
+
+// CheckSortable returns an error if the encoded samples,
+// compared by strings.Compare, are not in the same order
+// as the binary samples compared by bytes.Compare.
+// Only the samples having the same length are compared.
+// This helper is intended for the tests of the sortable identifiers.
+func CheckSortable(encode func(bin []byte) string, samples [][]byte) error {
+	encoded := make([]string, len(samples))
+	for i, bin := range samples {
+		encoded[i] = encode(bin)
+	}
+
+	for i, a := range samples {
+		for j, b := range samples {
+			if len(a) != len(b) {
+				continue
+			}
+			if bytes.Compare(a, b) != strings.Compare(encoded[i], encoded[j]) {
+				return fmt.Errorf("order of %x and %x is not preserved by %q and %q", a, b, encoded[i], encoded[j])
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+type sortable interface {
+	encoding.Encoder
+	EncodeToString(bin []byte) string
+	DecodeString(str string) ([]byte, error)
+}
+
+func TestSortable(t *testing.T) {
+	encodings := []sortable{
+		base36.StdEncoding.Sortable(),
+		base58.SortableEncoding,
+		base62.SortableEncoding,
+		base92.SortableEncoding,
+	}
+
+	for _, size := range []int{0, 1, 2, 7, 16, 33} {
+		samples := make([][]byte, 100)
+		for i := range samples {
+			samples[i] = make([]byte, size)
+			rand.Read(samples[i])
+			if i%10 == 0 && size > 1 {
+				samples[i][0] = 0 // leading zeros
+			}
+		}
+		if size > 0 {
+			samples[0] = make([]byte, size)              // lowest value
+			samples[1] = bytes.Repeat([]byte{255}, size) // highest value
+		}
+
+		for _, enc := range encodings {
+			radix := enc.Alphabet().Radix()
+
+			if err := encoding.CheckSortable(enc.EncodeToString, samples); err != nil {
+				t.Fatalf("Base%d: %v", radix, err)
+			}
+
+			for _, bin := range samples {
+				str := enc.EncodeToString(bin)
+				if len(str) != enc.Alphabet().FixedLen(size) {
+					t.Fatalf("Base%d: EncodeToString(%x) = %q, want %d characters", radix, bin, str, enc.Alphabet().FixedLen(size))
+				}
+				got, err := enc.DecodeString(str)
+				if err != nil || !bytes.Equal(got, bin) {
+					t.Fatalf("Base%d: DecodeString(%q) = %x, %v, want %x", radix, str, got, err, bin)
+				}
+			}
+		}
+	}
+}
+
+func TestFixedLen(t *testing.T) {
+	cases := []struct {
+		radix, n, want int
+	}{
+		{16, 16, 32},
+		{32, 5, 8},
+		{32, 1, 2},
+		{58, 16, 22},
+		{62, 16, 22},
+		{64, 3, 4},
+		{92, 16, 20},
+		{10, 1, 3},
+		{10, 0, 0},
+	}
+
+	for _, c := range cases {
+		enc := encoding.NewEncoding(alphabet128[:c.radix], c.radix)
+		if got := enc.FixedLen(c.n); got != c.want {
+			t.Errorf("Base%d: FixedLen(%d) = %d, want %d", c.radix, c.n, got, c.want)
+		}
+	}
+}
+
+// alphabet128 is the ASCII table, in ascending order.
+var alphabet128 = func() string {
+	b := make([]byte, 0, 128)
+	for c := 0; c < 128; c++ {
+		b = append(b, byte(c))
+	}
+	return string(b)
+}()
+
+func TestDecodeFixed_Errors(t *testing.T) {
+	enc := base58.SortableEncoding
+
+	for _, str := range []string{
+		"1",                      // no byte length encodes into 1 digit
+		"zzzzzzzzzzzzzzzzzzzzzz", // overflow of 16 bytes
+		"111111111110111111111111",
+	} {
+		if got, err := enc.DecodeString(str); err == nil {
+			t.Errorf("DecodeString(%q) = %x, want an error", str, got)
+		}
+	}
+}
+
+func TestCheckSortable(t *testing.T) {
+	samples := [][]byte{{1, 0}, {16, 0}, {0, 0, 1}}
+
+	if err := encoding.CheckSortable(base62.StdEncoding.EncodeToString, samples); err == nil {
+		t.Errorf("CheckSortable() must detect the variable length: %q > %q",
+			base62.StdEncoding.EncodeToString(samples[0]), base62.StdEncoding.EncodeToString(samples[1]))
+	}
+
+	if err := encoding.CheckSortable(base62.SortableEncoding.EncodeToString, samples); err != nil {
+		t.Errorf("CheckSortable() error = %v", err)
+	}
+}
+
+func TestSortable_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on unsorted alphabet did not occur")
+		}
+	}()
+	base58.FlickrEncoding.Sortable()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec is implemented by the Encoding types of all the BaseXX packages.
+type Codec interface {
+	EncodeToString(bin []byte) string
+	DecodeBytes(src []byte) ([]byte, error)
+}
+
+// Format provides the Codec of a Text type.
+// Implement it with an empty struct to plug a custom alphabet:
+//
+//	type myFormat struct{}
+//
+//	func (myFormat) Codec() encoding.Codec { return myEncoding }
+//
+//	type MyBytes = encoding.Text[myFormat]
+type Format interface {
+	Codec() Codec
+}
+
+// Text is a byte slice appearing as a string encoded by the Codec of F
+// in the text formats (encoding.TextMarshaler), in JSON,
+// and in the database text columns (sql.Scanner and driver.Valuer).
+// The nil slice is the JSON null and the SQL NULL.
+// The Bytes type of each package is a Text using its StdEncoding.
+type Text[F Format] []byte
+
+func codec[F Format]() Codec {
+	var f F
+	return f.Codec()
+}
+
+// String returns the encoded string.
+func (t Text[F]) String() string {
+	return codec[F]().EncodeToString(t)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Text[F]) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Text[F]) UnmarshalText(text []byte) error {
+	bin, err := codec[F]().DecodeBytes(text)
+	if err != nil {
+		return err
+	}
+	if bin == nil {
+		bin = []byte{} // the empty string is not null
+	}
+	*t = bin
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Text[F]) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Text[F]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = nil
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(str))
+}
+
+// Scan implements sql.Scanner for the text columns.
+func (t *Text[F]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	}
+	return fmt.Errorf("encoding: cannot scan %T into a Text", src)
+}
+
+// Value implements driver.Valuer: the encoded string, or NULL for a nil slice.
+func (t Text[F]) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return t.String(), nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	basexx "github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+// the Bytes types implement the interfaces
+var (
+	_ encoding.TextMarshaler   = base58.Bytes{}
+	_ encoding.TextUnmarshaler = &base58.Bytes{}
+	_ json.Marshaler           = base58.Bytes{}
+	_ json.Unmarshaler         = &base58.Bytes{}
+	_ sql.Scanner              = &base58.Bytes{}
+	_ driver.Valuer            = base58.Bytes{}
+)
+
+type flickrFormat struct{}
+
+func (flickrFormat) Codec() basexx.Codec { return base58.FlickrEncoding }
+
+type record struct {
+	B32    base32.Bytes              `json:"b32"`
+	B36    base36.Bytes              `json:"b36"`
+	B45    base45.Bytes              `json:"b45"`
+	B58    base58.Bytes              `json:"b58"`
+	B62    base62.Bytes              `json:"b62"`
+	B91    base91.Bytes              `json:"b91"`
+	B92    base92.Bytes              `json:"b92"`
+	A85    xascii85.Bytes            `json:"a85"`
+	Z85    z85.Bytes                 `json:"z85"`
+	Flickr basexx.Text[flickrFormat] `json:"flickr"`
+	Nil    base58.Bytes              `json:"nil"`
+	Empty  base62.Bytes              `json:"empty"`
+}
+
+func TestText_JSON(t *testing.T) {
+	bin := []byte("Hello, World!")
+	in := record{
+		B32: bin, B36: bin, B45: bin, B58: bin, B62: bin, B91: bin, B92: bin,
+		A85: bin, Z85: bin, Flickr: bin, Empty: []byte{},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["b58"] != base58.StdEncoding.EncodeToString(bin) ||
+		fields["flickr"] != base58.FlickrEncoding.EncodeToString(bin) ||
+		fields["nil"] != nil || fields["empty"] != "" {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var out record
+	if err = json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range [][]byte{out.B32, out.B36, out.B45, out.B58, out.B62, out.B91, out.B92, out.A85, out.Z85, out.Flickr} {
+		if !bytes.Equal(b, bin) {
+			t.Errorf("json.Unmarshal(%s) = %+v", data, out)
+		}
+	}
+	if out.Nil != nil || out.Empty == nil {
+		t.Errorf("json.Unmarshal() must keep null as nil and \"\" as empty: %#v, %#v", out.Nil, out.Empty)
+	}
+
+	if err = json.Unmarshal([]byte(`{"b58":"0OIl"}`), &out); err == nil {
+		t.Error("json.Unmarshal() must reject the invalid Base58 characters")
+	}
+}
+
+func TestText_SQL(t *testing.T) {
+	bin := []byte{0, 1, 2, 254, 255}
+	str := base62.StdEncoding.EncodeToString(bin)
+
+	v, err := base62.Bytes(bin).Value()
+	if err != nil || v != str {
+		t.Errorf("Value() = %v, %v, want %q", v, err, str)
+	}
+	if v, err = base62.Bytes(nil).Value(); err != nil || v != nil {
+		t.Errorf("Value() = %v, %v, want NULL", v, err)
+	}
+
+	for _, src := range []any{str, []byte(str)} {
+		var b base62.Bytes
+		if err := b.Scan(src); err != nil || !bytes.Equal(b, bin) {
+			t.Errorf("Scan(%v) = %x, %v, want %x", src, b, err, bin)
+		}
+	}
+
+	b := base62.Bytes(bin)
+	if err := b.Scan(nil); err != nil || b != nil {
+		t.Errorf("Scan(nil) = %x, %v, want nil", b, err)
+	}
+	if err := b.Scan(42); err == nil {
+		t.Error("Scan(42) must fail")
+	}
+}
+
+func TestText_String(t *testing.T) {
+	var b base58.Bytes
+	if err := b.UnmarshalText([]byte("2NEpo7TZRRrLZSi2U")); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "Hello World!" || b.String() != "2NEpo7TZRRrLZSi2U" {
+		t.Errorf("UnmarshalText() = %q (%s)", b, b)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+)
+
+// RandomString returns length characters drawn from crypto/rand,
+// each one uniform over the alphabet of enc.
+// The random bytes out of the largest multiple of the radix are rejected,
+// so that no character is more likely than another (no modulo bias).
+//
+// The output formatting of enc, if any, is applied (see WithGroups):
+// the separators are not counted in length.
+func RandomString(enc Encoder, length int) (string, error) {
+	alphabet := enc.Alphabet()
+	radix := alphabet.Radix()
+
+	if length < 0 {
+		return "", fmt.Errorf("Base%d: negative token length %d", radix, length)
+	}
+
+	// the random bytes >= limit would bias the lowest digits
+	limit := 256 - 256%radix
+
+	out := make([]byte, 0, length)
+	buf := make([]byte, length+length/4+8)
+	for len(out) < length {
+		if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+			return "", fmt.Errorf("Base%d: cannot read random bytes: %w", radix, err)
+		}
+		for _, b := range buf {
+			if int(b) < limit {
+				out = append(out, alphabet.EncChars[int(b)%radix])
+				if len(out) == length {
+					break
+				}
+			}
+		}
+	}
+
+	return string(alphabet.Formatted(out)), nil
+}
+
+// NewToken returns a random string having at least the given bits of entropy,
+// typically 128 for a session identifier.
+// All the tokens of an encoding have the same length: TokenLen(enc, bits).
+func NewToken(enc Encoder, bits int) (string, error) {
+	if bits <= 0 {
+		return "", fmt.Errorf("Base%d: invalid token entropy %d bits", enc.Alphabet().Radix(), bits)
+	}
+	return RandomString(enc, TokenLen(enc, bits))
+}
+
+// TokenLen returns the number of characters required
+// to reach the given bits of entropy with the alphabet of enc.
+func TokenLen(enc Encoder, bits int) int {
+	perChar := math.Log2(float64(enc.Alphabet().Radix()))
+	// subtract a tiny epsilon to absorb the floating point rounding
+	// when bits is a multiple of perChar (e.g. 128 bits in Base16)
+	return int(math.Ceil(float64(bits)/perChar - 1e-9))
+}
+
+// Entropy returns the entropy in bits of a string of length characters
+// drawn by RandomString: length × log2(radix).
+func Entropy(enc Encoder, length int) float64 {
+	return float64(length) * math.Log2(float64(enc.Alphabet().Radix()))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+func TestNewToken(t *testing.T) {
+	cases := []struct {
+		name string
+		enc  encoding.Encoder
+		bits int
+		want int // token length
+	}{
+		{"base16", encoding.NewEncoding("0123456789abcdef", 16), 128, 32},
+		{"base32", base32.StdEncoding, 128, 26},
+		{"base58", base58.StdEncoding, 128, 22},
+		{"base62", base62.StdEncoding, 128, 22},
+		{"base91", base91.StdEncoding, 128, 20},
+		{"base92", base92.StdEncoding, 128, 20},
+		{"base92-1bit", base92.StdEncoding, 1, 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if n := encoding.TokenLen(c.enc, c.bits); n != c.want {
+				t.Errorf("TokenLen(%d) = %d, want %d", c.bits, n, c.want)
+			}
+			if e := encoding.Entropy(c.enc, c.want); e < float64(c.bits) {
+				t.Errorf("Entropy(%d) = %g, want at least %d", c.want, e, c.bits)
+			}
+
+			for i := 0; i < 100; i++ {
+				token, err := encoding.NewToken(c.enc, c.bits)
+				if err != nil {
+					t.Fatalf("NewToken() error = %v", err)
+				}
+				if len(token) != c.want {
+					t.Fatalf("NewToken() = %q, want %d characters", token, c.want)
+				}
+				for j := 0; j < len(token); j++ {
+					if c.enc.Alphabet().DecMap[token[j]] == -1 {
+						t.Fatalf("NewToken() = %q has a character not in the alphabet", token)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRandomString_Uniform(t *testing.T) {
+	enc := base62.StdEncoding
+	const length = 62 * 1000
+
+	str, err := encoding.RandomString(enc, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// chi-squared test with 61 degrees of freedom:
+	// the 99.99% quantile is about 114
+	var counts [62]int
+	for i := 0; i < len(str); i++ {
+		counts[enc.DecMap[str[i]]]++
+	}
+	expected := float64(length) / 62
+	chi2 := 0.0
+	for _, n := range counts {
+		chi2 += math.Pow(float64(n)-expected, 2) / expected
+	}
+	if chi2 > 114 {
+		t.Errorf("the characters are not uniform: chi2 = %g, counts = %v", chi2, counts)
+	}
+}
+
+func TestRandomString_Format(t *testing.T) {
+	str, err := encoding.RandomString(base58.StdEncoding.WithGroups(4, "-"), 12)
+	if err != nil || len(str) != 14 || strings.Count(str, "-") != 2 {
+		t.Errorf("RandomString() = %q, %v, want 3 groups of 4 characters", str, err)
+	}
+
+	if str, err := encoding.RandomString(base58.StdEncoding, 0); err != nil || str != "" {
+		t.Errorf("RandomString(0) = %q, %v, want empty", str, err)
+	}
+
+	if _, err := encoding.RandomString(base58.StdEncoding, -1); err == nil {
+		t.Errorf("RandomString(-1) must fail")
+	}
+	if _, err := encoding.NewToken(base58.StdEncoding, 0); err == nil {
+		t.Errorf("NewToken(0 bits) must fail")
+	}
+}
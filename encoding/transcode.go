@@ -0,0 +1,115 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"fmt"
+)
+
+// Encoder is implemented by the Encoding type of the radix packages
+// (base36, base58, base62, base91 and base92) to expose their alphabet
+// to the functions of this package.
+type Encoder interface {
+	Alphabet() *Encoding
+}
+
+// Alphabet returns enc itself, so that *Encoding implements Encoder.
+func (enc *Encoding) Alphabet() *Encoding { return enc }
+
+// Radix returns the base of the encoding, the number of characters of its alphabet.
+func (enc *Encoding) Radix() int { return len(enc.EncChars) }
+
+// Transcode converts s, encoded with src, into the dst encoding.
+// The result is the same as dst.EncodeToString(src.DecodeString(s)),
+// including the leading zeros modes of both encodings (see WithLeadingZeros).
+// When both encodings are in the BitcoinZeros or IntegerZeros mode,
+// the conversion is done directly between the two radixes,
+// without materializing the binary bytes.
+//
+// The ignored characters of src are skipped
+// and the output formatting of dst is applied (see WithGroups).
+func Transcode(src, dst Encoder, s string) (string, error) {
+	from, to := src.Alphabet(), dst.Alphabet()
+	s = from.Strip(s)
+
+	// the fixed width depends on the number of bytes: decode them
+	if from.zeros == LengthPreserving || to.zeros == LengthPreserving {
+		bin, err := from.decodeBytes(s)
+		if err != nil {
+			return "", err
+		}
+		return string(to.encodeBytes(bin)), nil
+	}
+
+	digits, err := from.digits(s)
+	if err != nil {
+		return "", err
+	}
+
+	// each leading zero digit of src becomes one leading zero digit of dst,
+	// unless one of the encodings drops them
+	if from.zeros == IntegerZeros {
+		digits = TrimZeros(digits)
+	}
+	out := ConvertRadix(digits, from.Radix(), to.Radix())
+	if to.zeros == IntegerZeros {
+		out = TrimZeros(out)
+	}
+
+	for i, d := range out {
+		out[i] = to.EncChars[d]
+	}
+	return string(to.Formatted(out)), nil
+}
+
+// digits returns the digit values of the characters of s.
+func (enc *Encoding) digits(s string) ([]byte, error) {
+	digits := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c > 127 {
+			return nil, fmt.Errorf("Base%d: high-bit set on invalid digit", enc.Radix())
+		}
+		if enc.DecMap[c] == -1 {
+			return nil, fmt.Errorf("Base%d: invalid digit %q", enc.Radix(), c)
+		}
+		digits[i] = byte(enc.DecMap[c])
+	}
+	return digits, nil
+}
+
+// decodeBytes decodes the stripped s as the radix packages do
+// in the leading zeros mode of enc.
+func (enc *Encoding) decodeBytes(s string) ([]byte, error) {
+	if enc.zeros == LengthPreserving {
+		return DecodeFixed(enc, s)
+	}
+
+	digits, err := enc.digits(s)
+	if err != nil {
+		return nil, err
+	}
+	if enc.zeros == IntegerZeros {
+		digits = TrimZeros(digits)
+	}
+	return ConvertRadix(digits, enc.Radix(), 256), nil
+}
+
+// encodeBytes encodes bin as the radix packages do
+// in the leading zeros mode of enc.
+func (enc *Encoding) encodeBytes(bin []byte) []byte {
+	switch enc.zeros {
+	case LengthPreserving:
+		return enc.Formatted(enc.EncodeFixed(bin))
+	case IntegerZeros:
+		bin = TrimZeros(bin)
+	}
+
+	out := ConvertRadix(bin, 256, enc.Radix())
+	for i, d := range out {
+		out[i] = enc.EncChars[d]
+	}
+	return enc.Formatted(out)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+// codec is implemented by the Encoding types of the radix packages.
+type codec interface {
+	encoding.Encoder
+	EncodeToString(bin []byte) string
+	DecodeString(str string) ([]byte, error)
+}
+
+var codecs = map[string]codec{
+	"base58":       base58.StdEncoding,
+	"base58Flickr": base58.FlickrEncoding,
+	"base62":       base62.StdEncoding,
+	"base91":       base91.StdEncoding,
+	"base92":       base92.StdEncoding,
+}
+
+var samples = [][]byte{
+	nil,
+	{0},
+	{0, 0, 0},
+	{1},
+	{255},
+	{0, 0, 1, 2, 3},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255},
+	[]byte("Garçon, un café très fort !"),
+}
+
+func TestTranscode(t *testing.T) {
+	samples := append([][]byte(nil), samples...) // do not modify the shared samples
+	for i := 0; i < 50; i++ {
+		b := make([]byte, rand.Intn(64))
+		rand.Read(b)
+		if len(b) > 1 && i%3 == 0 {
+			b[0] = 0 // keep some leading zeros
+		}
+		samples = append(samples, b)
+	}
+
+	for srcName, src := range codecs {
+		for dstName, dst := range codecs {
+			for _, bin := range samples {
+				s := src.EncodeToString(bin)
+				want := dst.EncodeToString(bin)
+
+				got, err := encoding.Transcode(src, dst, s)
+				if err != nil {
+					t.Fatalf("%s -> %s: Transcode(%q) error = %v", srcName, dstName, s, err)
+				}
+				if got != want {
+					t.Errorf("%s -> %s: Transcode(%q) = %q, want %q", srcName, dstName, s, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestTranscode_InvalidDigit(t *testing.T) {
+	for _, s := range []string{"0", "1O", "abc\xff"} {
+		if _, err := encoding.Transcode(base58.StdEncoding, base62.StdEncoding, s); err == nil {
+			t.Errorf("Transcode(%q) expected an error", s)
+		}
+	}
+}
+
+func TestTranscode_LeadingZeros(t *testing.T) {
+	codecs := map[string]codec{
+		"base58":         base58.StdEncoding,
+		"base58Integer":  base58.StdEncoding.WithLeadingZeros(encoding.IntegerZeros),
+		"base58Sortable": base58.SortableEncoding,
+		"base62Integer":  base62.StdEncoding.WithLeadingZeros(encoding.IntegerZeros),
+		"base62Sortable": base62.SortableEncoding,
+		"base91Length":   base91.StdEncoding.WithLeadingZeros(encoding.LengthPreserving),
+		"base92":         base92.StdEncoding,
+	}
+
+	for srcName, src := range codecs {
+		for dstName, dst := range codecs {
+			for _, bin := range samples {
+				s := src.EncodeToString(bin)
+				dec, err := src.DecodeString(s)
+				if err != nil {
+					t.Fatalf("%s: DecodeString(%q) error = %v", srcName, s, err)
+				}
+				want := dst.EncodeToString(dec)
+
+				got, err := encoding.Transcode(src, dst, s)
+				if err != nil {
+					t.Fatalf("%s -> %s: Transcode(%q) error = %v", srcName, dstName, s, err)
+				}
+				if got != want {
+					t.Errorf("%s -> %s: Transcode(%q) = %q, want %q", srcName, dstName, s, got, want)
+				}
+			}
+		}
+	}
+
+	if got, err := encoding.Transcode(base58.SortableEncoding, base58.StdEncoding, "11116"); err != nil || got != "116" {
+		t.Errorf("Transcode(11116) = %q, %v, want 116", got, err)
+	}
+	if _, err := encoding.Transcode(base58.SortableEncoding, base58.StdEncoding, "1116"); err == nil {
+		t.Errorf("Transcode(1116) expected an error on invalid sortable length")
+	}
+}
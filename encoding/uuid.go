@@ -0,0 +1,137 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+)
+
+// UUIDLen returns the length of the UUIDs encoded by EncodeUUID,
+// FixedLen(16): 22 characters in Base58 and Base62, 20 in Base91 and Base92.
+func (enc *Encoding) UUIDLen() int { return enc.FixedLen(16) }
+
+// EncodeUUID encodes the 128-bit value of uuid into UUIDLen() characters,
+// left-padded with the zero digit, as EncodeFixed does.
+// The conversion is done on two uint64 without allocating big integers:
+// the only allocation is the returned string.
+func (enc *Encoding) EncodeUUID(uuid [16]byte) string {
+	var digits [128]byte // the longest encoding is 128 binary digits
+
+	radix := uint64(enc.Radix())
+	hi := binary.BigEndian.Uint64(uuid[:8])
+	lo := binary.BigEndian.Uint64(uuid[8:])
+
+	n := enc.UUIDLen()
+	for i := n - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, radix)
+		lo, rem = bits.Div64(rem, lo, radix)
+		digits[i] = enc.EncChars[rem]
+	}
+
+	return string(enc.Formatted(digits[:n]))
+}
+
+// DecodeUUID decodes a UUID encoded by EncodeUUID.
+// It also accepts the canonical text representation of the UUIDs,
+// such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" (see ParseUUID),
+// when the string without the ignored characters
+// does not have the UUIDLen() characters of an encoded UUID.
+// DecodeUUID does not allocate memory
+// (unless the string contains ignored characters, see WithIgnore).
+func (enc *Encoding) DecodeUUID(str string) (uuid [16]byte, err error) {
+	n := enc.UUIDLen()
+	stripped := enc.Strip(str)
+	if len(stripped) != n && len(str) == 36 {
+		return ParseUUID(str)
+	}
+
+	str = stripped
+	if len(str) != n {
+		return uuid, fmt.Errorf("Base%d: invalid UUID length %d, want %d", enc.Radix(), len(str), n)
+	}
+
+	radix := uint64(enc.Radix())
+	var hi, lo uint64
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c > 127 {
+			return uuid, fmt.Errorf("Base%d: high-bit set on invalid digit", enc.Radix())
+		}
+		if enc.DecMap[c] == -1 {
+			return uuid, fmt.Errorf("Base%d: invalid digit %q", enc.Radix(), c)
+		}
+
+		// (hi, lo) = (hi, lo) * radix + digit
+		overflow, hiLo := bits.Mul64(hi, radix)
+		carry, loLo := bits.Mul64(lo, radix)
+		var c1, c2 uint64
+		lo, c1 = bits.Add64(loLo, uint64(enc.DecMap[c]), 0)
+		hi, c2 = bits.Add64(hiLo, carry+c1, 0)
+		if overflow != 0 || c2 != 0 {
+			return uuid, fmt.Errorf("Base%d: UUID value exceeds 128 bits", enc.Radix())
+		}
+	}
+
+	binary.BigEndian.PutUint64(uuid[:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+	return uuid, nil
+}
+
+// ParseUUID parses the canonical text representation of a UUID,
+// 32 hexadecimal digits (in lower or upper case) grouped as 8-4-4-4-12,
+// such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" (RFC 4122).
+func ParseUUID(text string) (uuid [16]byte, err error) {
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return uuid, fmt.Errorf("invalid UUID format %q", text)
+	}
+
+	j := 0
+	for i := 0; i < 36; i += 2 {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			i++ // skip the hyphen
+		}
+		h, ok1 := fromHex(text[i])
+		l, ok2 := fromHex(text[i+1])
+		if !ok1 || !ok2 {
+			return uuid, fmt.Errorf("invalid UUID format %q", text)
+		}
+		uuid[j] = h<<4 | l
+		j++
+	}
+
+	return uuid, nil
+}
+
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// FormatUUID returns the canonical text representation of uuid
+// in lower case, such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func FormatUUID(uuid [16]byte) string {
+	var text [36]byte
+	hex.Encode(text[0:8], uuid[0:4])
+	text[8] = '-'
+	hex.Encode(text[9:13], uuid[4:6])
+	text[13] = '-'
+	hex.Encode(text[14:18], uuid[6:8])
+	text[18] = '-'
+	hex.Encode(text[19:23], uuid[8:10])
+	text[23] = '-'
+	hex.Encode(text[24:], uuid[10:])
+	return string(text[:])
+}
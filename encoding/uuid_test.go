@@ -0,0 +1,150 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+type uuidEncoder interface {
+	encoding.Encoder
+	EncodeUUID(uuid [16]byte) string
+	DecodeUUID(str string) ([16]byte, error)
+}
+
+func TestUUID(t *testing.T) {
+	cases := []struct {
+		enc  uuidEncoder
+		want int
+	}{
+		{base36.StdEncoding, 25},
+		{base58.StdEncoding, 22},
+		{base62.StdEncoding, 22},
+		{base91.StdEncoding, 20},
+		{base92.StdEncoding, 20},
+	}
+
+	uuids := make([][16]byte, 100)
+	for i := range uuids {
+		rand.Read(uuids[i][:])
+		if i%10 == 1 {
+			uuids[i][0], uuids[i][1] = 0, 0 // leading zeros
+		}
+	}
+	uuids[0] = [16]byte{}
+	copy(uuids[1][:], bytes.Repeat([]byte{255}, 16))
+
+	for _, c := range cases {
+		radix := c.enc.Alphabet().Radix()
+		if got := c.enc.Alphabet().UUIDLen(); got != c.want {
+			t.Errorf("Base%d: UUIDLen() = %d, want %d", radix, got, c.want)
+		}
+
+		for _, uuid := range uuids {
+			str := c.enc.EncodeUUID(uuid)
+			if len(str) != c.want {
+				t.Fatalf("Base%d: EncodeUUID(%x) = %q, want %d characters", radix, uuid, str, c.want)
+			}
+			got, err := c.enc.DecodeUUID(str)
+			if err != nil || got != uuid {
+				t.Fatalf("Base%d: DecodeUUID(%q) = %x, %v, want %x", radix, str, got, err, uuid)
+			}
+
+			// same value as the variable-length encoding
+			bin, err := c.enc.Alphabet().DecodeFixed(str)
+			if err != nil || !bytes.Equal(bin, uuid[:]) {
+				t.Fatalf("Base%d: DecodeFixed(%q) = %x, %v, want %x", radix, str, bin, err, uuid)
+			}
+
+			got, err = c.enc.DecodeUUID(encoding.FormatUUID(uuid))
+			if err != nil || got != uuid {
+				t.Fatalf("Base%d: DecodeUUID(%q) = %x, %v, want %x", radix, encoding.FormatUUID(uuid), got, err, uuid)
+			}
+		}
+	}
+}
+
+func TestUUID_Groups(t *testing.T) {
+	for _, enc := range []uuidEncoder{
+		base58.StdEncoding.WithGroups(3, "--"), // 36 characters as the canonical form
+		base62.StdEncoding.WithGroups(4, "-"),
+		base91.StdEncoding.WithGroups(5, " "),
+	} {
+		for i := 0; i < 100; i++ {
+			var uuid [16]byte
+			rand.Read(uuid[:])
+			str := enc.EncodeUUID(uuid)
+			if got, err := enc.DecodeUUID(str); err != nil || got != uuid {
+				t.Fatalf("DecodeUUID(%q) = %x, %v, want %x", str, got, err, uuid)
+			}
+		}
+
+		const text = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+		if got, err := enc.DecodeUUID(text); err != nil || encoding.FormatUUID(got) != text {
+			t.Errorf("DecodeUUID(%q) = %x, %v, want the canonical UUID", text, got, err)
+		}
+	}
+}
+
+func TestUUID_Errors(t *testing.T) {
+	enc := base62.StdEncoding
+
+	for _, str := range []string{
+		"",
+		"0000000000000000000000000", // too long
+		"000000000000000000000",     // too short
+		"zzzzzzzzzzzzzzzzzzzzzz",    // overflow of 128 bits
+		"00000000000000000000-0",    // invalid digit
+		"0000000000000000000000\xff",
+		"f81d4fae-7dec-11d0-a765_00a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bfg",
+	} {
+		if got, err := enc.DecodeUUID(str); err == nil {
+			t.Errorf("DecodeUUID(%q) = %x, want an error", str, got)
+		}
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	const text = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	want := [16]byte{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+
+	for _, s := range []string{text, "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"} {
+		got, err := encoding.ParseUUID(s)
+		if err != nil || got != want {
+			t.Errorf("ParseUUID(%q) = %x, %v, want %x", s, got, err, want)
+		}
+	}
+
+	if got := encoding.FormatUUID(want); got != text {
+		t.Errorf("FormatUUID() = %q, want %q", got, text)
+	}
+
+	if got, err := encoding.ParseUUID("f81d4fae7dec11d0a76500a0c91e6bf6"); err == nil {
+		t.Errorf("ParseUUID() = %x, want an error on the non-hyphenated form", got)
+	}
+}
+
+func TestUUID_Allocs(t *testing.T) {
+	enc := base62.StdEncoding
+	uuid := [16]byte{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	str := enc.EncodeUUID(uuid)
+
+	if n := testing.AllocsPerRun(100, func() { _ = enc.EncodeUUID(uuid) }); n > 1 {
+		t.Errorf("EncodeUUID() allocates %v times, want only the returned string", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _, _ = enc.DecodeUUID(str) }); n > 0 {
+		t.Errorf("DecodeUUID() allocates %v times, want none", n)
+	}
+}
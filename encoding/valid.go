@@ -0,0 +1,96 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import "math"
+
+// Valid reports whether the radix packages can decode str,
+// checking the characters against DecMap (skipping the ignored characters)
+// and, in LengthPreserving mode, the number of digits and the value range.
+// Valid does not allocate memory.
+func (enc *Encoding) Valid(str string) bool {
+	return enc.ValidFor(str, math.MaxInt)
+}
+
+// ValidFor reports whether str is Valid and decodes into at most n bytes,
+// depending on the leading-zero mode (see WithLeadingZeros).
+// ValidFor does not allocate memory when n <= 512,
+// the value is only computed when its number of digits is not enough to decide.
+func (enc *Encoding) ValidFor(str string, n int) bool {
+	digits, zcount := 0, 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if enc.Ignored(c) {
+			continue
+		}
+		if c > 127 || enc.DecMap[c] == -1 {
+			return false
+		}
+		if digits == zcount && enc.DecMap[c] == 0 {
+			zcount++
+		}
+		digits++
+	}
+
+	switch enc.zeros {
+	case BitcoinZeros:
+		return zcount <= n && enc.fits(str, zcount, digits-zcount, n-zcount)
+	case IntegerZeros:
+		return enc.fits(str, zcount, digits-zcount, n)
+	default: // LengthPreserving
+		size := enc.fixedBytes(digits)
+		return size >= 0 && size <= n && enc.fits(str, zcount, digits-zcount, size)
+	}
+}
+
+// fits reports whether the value of the digits of str,
+// after the zcount leading zero digits, fits in size bytes.
+func (enc *Encoding) fits(str string, zcount, digits, size int) bool {
+	if size < 0 {
+		return false
+	}
+
+	// the digit count is often enough: value < radix^digits
+	maxBytes := math.Ceil(float64(digits) * math.Log2(float64(enc.Radix())) / 8)
+	if maxBytes <= float64(size) {
+		return true
+	}
+
+	var stack [512]byte
+	var value []byte
+	if size <= len(stack) {
+		value = stack[:size]
+	} else {
+		value = make([]byte, size)
+	}
+
+	// big-endian multiply-add, value[top:] holds the non-zero bytes
+	radix := uint(enc.Radix())
+	top := size
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if enc.Ignored(c) {
+			continue
+		}
+		if zcount > 0 {
+			zcount--
+			continue
+		}
+
+		carry := uint(enc.DecMap[c])
+		j := size - 1
+		for ; j >= top || carry != 0; j-- {
+			if j < 0 {
+				return false
+			}
+			carry += uint(value[j]) * radix
+			value[j] = byte(carry)
+			carry >>= 8
+		}
+		top = j + 1
+	}
+
+	return true
+}
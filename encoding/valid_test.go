@@ -0,0 +1,127 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base32"
+	"github.com/teal-finance/BaseXX/base45"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/encoding"
+	"github.com/teal-finance/BaseXX/xascii85"
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+type validator interface {
+	EncodeToString(bin []byte) string
+	DecodeString(str string) ([]byte, error)
+	Valid(str string) bool
+	ValidFor(str string, n int) bool
+}
+
+// mutations returns str and some variants, most of them invalid.
+func mutations(str string, r *rand.Rand) []string {
+	variants := []string{str, str + str, " " + str, str + "\n", str + "~>", "<~" + str, str + "===="}
+	if len(str) > 0 {
+		i := r.Intn(len(str))
+		variants = append(variants,
+			str[:i],
+			str[:i]+str[i+1:],
+			str[:i]+"-"+str[i:],
+			str[:i]+"0"+str[i+1:],
+			str[:i]+"z"+str[i+1:],
+			str[:i]+"\xff"+str[i+1:],
+			str[:i]+"\n"+str[i:],
+			strings.ToLower(str),
+			strings.Repeat(str[i:i+1], len(str)))
+	}
+	return variants
+}
+
+func TestValid(t *testing.T) {
+	validators := []validator{
+		z85.StdEncoding, z85.PaddedEncoding,
+		xascii85.StdEncoding, xascii85.AdobeEncoding,
+		base45.StdEncoding,
+		base32.StdEncoding, base32.HexEncoding.WithPadding(base32.NoPadding),
+		base32.CrockfordEncoding, base32.CrockfordEncoding.WithCheck(),
+	}
+	for _, mode := range []encoding.LeadingZeros{encoding.BitcoinZeros, encoding.IntegerZeros, encoding.LengthPreserving} {
+		for _, enc := range zerosEncoders(mode) {
+			validators = append(validators, enc.(validator))
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	invalid := 0
+	for v, enc := range validators {
+		for _, bin := range zerosSamples() {
+			if len(bin)%4 != 0 && enc == z85.StdEncoding {
+				continue
+			}
+			for _, str := range mutations(enc.EncodeToString(bin), r) {
+				got, err := enc.DecodeString(str)
+				if valid := enc.Valid(str); valid != (err == nil) {
+					t.Fatalf("#%d Valid(%q) = %v, but DecodeString() error = %v", v, str, valid, err)
+				}
+				if err != nil {
+					invalid++
+					continue
+				}
+				for _, n := range []int{len(got) - 1, len(got), len(got) + 1} {
+					if valid := enc.ValidFor(str, n); valid != (len(got) <= n) {
+						t.Fatalf("#%d ValidFor(%q, %d) = %v, but decoded length = %d", v, str, n, valid, len(got))
+					}
+				}
+			}
+		}
+	}
+	if invalid == 0 {
+		t.Error("no invalid mutation has been tested")
+	}
+}
+
+func TestValid_Allocs(t *testing.T) {
+	bin := make([]byte, 64)
+	rand.Read(bin)
+
+	for _, enc := range []validator{
+		base58.StdEncoding,
+		base58.StdEncoding.WithLeadingZeros(encoding.LengthPreserving),
+		z85.StdEncoding, xascii85.AdobeEncoding, base45.StdEncoding, base32.StdEncoding,
+	} {
+		str := enc.EncodeToString(bin)
+		if !enc.ValidFor(str, 64) || enc.ValidFor(str, 63) {
+			t.Errorf("ValidFor(%q) must accept 64 bytes and reject 63 bytes", str)
+		}
+		if n := testing.AllocsPerRun(100, func() { _ = enc.ValidFor(str, 63) }); n > 0 {
+			t.Errorf("ValidFor(%q) allocates %v times, want none", str, n)
+		}
+	}
+}
+
+func TestValid_Long(t *testing.T) {
+	bin := make([]byte, 4093)
+	rand.Read(bin)
+
+	for _, enc := range []validator{
+		z85.PaddedEncoding, xascii85.StdEncoding, xascii85.AdobeEncoding, base45.StdEncoding,
+		base32.StdEncoding, base32.CrockfordEncoding.WithCheck(),
+	} {
+		str := enc.EncodeToString(bin)
+		if !enc.ValidFor(str, len(bin)) || enc.ValidFor(str, len(bin)-1) {
+			t.Errorf("%T ValidFor() must accept %d bytes and reject %d bytes", enc, len(bin), len(bin)-1)
+		}
+		if enc.Valid(str[:len(str)/2] + "\xff" + str[len(str)/2:]) {
+			t.Errorf("%T Valid() must reject an invalid character in the middle", enc)
+		}
+		if n := testing.AllocsPerRun(100, func() { _ = enc.Valid(str) }); n > 0 {
+			t.Errorf("%T Valid() allocates %v times, want none", enc, n)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding
+
+import (
+	"log"
+	"strconv"
+)
+
+// LeadingZeros selects how the radix encodings handle the leading zero bytes.
+type LeadingZeros uint8
+
+const (
+	// BitcoinZeros encodes each leading 0x00 byte as one zero digit,
+	// as Bitcoin and mr-tron/base58 do. This is the default mode.
+	BitcoinZeros LeadingZeros = iota
+
+	// IntegerZeros considers the data as a big-endian unsigned integer:
+	// the leading zero bytes are dropped (the empty output encodes zero)
+	// and the decoded bytes never start with 0x00.
+	IntegerZeros
+
+	// LengthPreserving emits FixedLen(n) digits for n bytes,
+	// left-padded with the zero digit: the number of digits encodes
+	// the number of bytes, so the leading zero bytes are preserved
+	// and the output length depends only on the input length (see EncodeFixed).
+	LengthPreserving
+)
+
+// String returns the name of the mode.
+func (z LeadingZeros) String() string {
+	switch z {
+	case BitcoinZeros:
+		return "BitcoinZeros"
+	case IntegerZeros:
+		return "IntegerZeros"
+	case LengthPreserving:
+		return "LengthPreserving"
+	}
+	return "LeadingZeros(" + strconv.Itoa(int(z)) + ")"
+}
+
+// WithLeadingZeros returns a copy of the encoding handling the leading zero bytes
+// as selected by mode. Other modes than LengthPreserving cancel the sortable mode.
+// It panics on an unknown mode.
+func (enc *Encoding) WithLeadingZeros(mode LeadingZeros) *Encoding {
+	if mode > LengthPreserving {
+		log.Panicf("Base%d: unknown leading-zero mode %d", enc.Radix(), mode)
+	}
+	ret := *enc
+	ret.zeros = mode
+	ret.sortable = ret.sortable && mode == LengthPreserving
+	return &ret
+}
+
+// LeadingZeros returns the leading-zero mode of the encoding.
+func (enc *Encoding) LeadingZeros() LeadingZeros { return enc.zeros }
+
+// TrimZeros returns bin without its leading zero bytes.
+func TrimZeros(bin []byte) []byte {
+	for len(bin) > 0 && bin[0] == 0 {
+		bin = bin[1:]
+	}
+	return bin
+}
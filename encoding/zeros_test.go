@@ -0,0 +1,129 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package encoding_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base36"
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/base91"
+	"github.com/teal-finance/BaseXX/base92"
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+func zerosEncoders(mode encoding.LeadingZeros) []sortable {
+	return []sortable{
+		base36.StdEncoding.WithLeadingZeros(mode),
+		base58.StdEncoding.WithLeadingZeros(mode),
+		base62.StdEncoding.WithLeadingZeros(mode),
+		base91.StdEncoding.WithLeadingZeros(mode),
+		base92.StdEncoding.WithLeadingZeros(mode),
+	}
+}
+
+func zerosSamples() [][]byte {
+	samples := [][]byte{nil, {0}, {0, 0, 0}, {0, 0, 1}, {1, 0}, {255}}
+	for i := 0; i < 100; i++ {
+		bin := make([]byte, i%40)
+		rand.Read(bin)
+		for j := 0; j < i%5 && j < len(bin); j++ {
+			bin[j] = 0 // leading zeros
+		}
+		samples = append(samples, bin)
+	}
+	return samples
+}
+
+func TestLeadingZeros_Bitcoin(t *testing.T) {
+	for _, enc := range zerosEncoders(encoding.BitcoinZeros) {
+		radix := enc.Alphabet().Radix()
+		zero := enc.Alphabet().EncChars[0]
+
+		for _, bin := range zerosSamples() {
+			str := enc.EncodeToString(bin)
+			zcount := len(bin) - len(encoding.TrimZeros(bin))
+			if len(str) < zcount || str[:zcount] != string(bytes.Repeat([]byte{zero}, zcount)) {
+				t.Fatalf("Base%d: EncodeToString(%x) = %q, want %d leading zero digits", radix, bin, str, zcount)
+			}
+			got, err := enc.DecodeString(str)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("Base%d: DecodeString(%q) = %x, %v, want %x", radix, str, got, err, bin)
+			}
+		}
+	}
+}
+
+func TestLeadingZeros_Integer(t *testing.T) {
+	for _, enc := range zerosEncoders(encoding.IntegerZeros) {
+		radix := enc.Alphabet().Radix()
+
+		for _, bin := range zerosSamples() {
+			want := encoding.TrimZeros(bin)
+			str := enc.EncodeToString(bin)
+			if len(want) > 0 && str[0] == enc.Alphabet().EncChars[0] {
+				t.Fatalf("Base%d: EncodeToString(%x) = %q, want no leading zero digit", radix, bin, str)
+			}
+			if len(want) == 0 && str != "" {
+				t.Fatalf("Base%d: EncodeToString(%x) = %q, want empty string", radix, bin, str)
+			}
+			got, err := enc.DecodeString(str)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Fatalf("Base%d: DecodeString(%q) = %x, %v, want %x", radix, str, got, err, want)
+			}
+
+			// the leading zero digits do not change the value
+			zeros := string(enc.Alphabet().EncChars[:1])
+			got, err = enc.DecodeString(zeros + zeros + str)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Fatalf("Base%d: DecodeString(%q) = %x, %v, want %x", radix, zeros+zeros+str, got, err, want)
+			}
+		}
+	}
+}
+
+func TestLeadingZeros_LengthPreserving(t *testing.T) {
+	for _, enc := range zerosEncoders(encoding.LengthPreserving) {
+		radix := enc.Alphabet().Radix()
+
+		for _, bin := range zerosSamples() {
+			str := enc.EncodeToString(bin)
+			if len(str) != enc.Alphabet().FixedLen(len(bin)) {
+				t.Fatalf("Base%d: EncodeToString(%x) = %q, want %d digits", radix, bin, str, enc.Alphabet().FixedLen(len(bin)))
+			}
+			got, err := enc.DecodeString(str)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("Base%d: DecodeString(%q) = %x, %v, want %x", radix, str, got, err, bin)
+			}
+		}
+	}
+
+}
+
+func TestLeadingZeros_Sortable(t *testing.T) {
+	enc := base62.StdEncoding.Alphabet()
+
+	if enc.Sortable().LeadingZeros() != encoding.LengthPreserving {
+		t.Errorf("Sortable() mode = %v, want LengthPreserving", enc.Sortable().LeadingZeros())
+	}
+	if enc.Sortable().WithLeadingZeros(encoding.BitcoinZeros).IsSortable() {
+		t.Errorf("WithLeadingZeros(BitcoinZeros) must cancel the sortable mode")
+	}
+	if enc.WithLeadingZeros(encoding.LengthPreserving).IsSortable() {
+		t.Errorf("WithLeadingZeros(LengthPreserving) must not check the alphabet order")
+	}
+}
+
+func TestLeadingZeros_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on unknown mode did not occur")
+		}
+	}()
+	base58.StdEncoding.WithLeadingZeros(encoding.LengthPreserving + 1)
+}
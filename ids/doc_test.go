@@ -0,0 +1,42 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package ids_test
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/teal-finance/BaseXX/ids"
+)
+
+// Deterministic IDs using a fixed clock and random source.
+func ExampleGenerator_New() {
+	clock := func() time.Time { return time.Unix(1_650_000_000, 0) }
+	random := bytes.NewReader(bytes.Repeat([]byte{0xAB}, 16))
+	g := ids.NewGenerator(clock, random)
+
+	id1, _ := g.New()
+	id2, _ := g.New() // same second: id1 + 1
+
+	fmt.Println(id1)
+	fmt.Println(id2)
+	fmt.Println(id1.Time().UTC())
+	// Output:
+	// E21TdAM1fZoLvyZv9b2jhJQqyaZ
+	// E21TdAM1fZoLvyZv9b2jhJQqyaa
+	// 2022-04-15 05:20:00 +0000 UTC
+}
+
+func ExampleParse() {
+	id, err := ids.Parse("E21TdAM1fZoLvyZv9b2jhJQqyaa")
+
+	fmt.Println(id.Time().UTC())
+	fmt.Printf("%x\n", id.Payload())
+	fmt.Println("Error:", err)
+	// Output:
+	// 2022-04-15 05:20:00 +0000 UTC
+	// abababababababababababababababac
+	// Error: <nil>
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Package ids generates time-sortable unique identifiers (KSUID-style):
+// 20 bytes made of a 32-bit timestamp (seconds since the Unix epoch)
+// followed by a 128-bit random payload, encoded with base62.StdEncoding
+// into fixed 27-character strings sorting in chronological order.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/teal-finance/BaseXX/base62"
+)
+
+const (
+	// Size is the number of bytes of an ID.
+	Size = 20
+	// Len is the number of characters of an encoded ID.
+	Len = 27
+
+	timestampSize = 4
+)
+
+// ID is a 32-bit big-endian timestamp followed by a 128-bit payload.
+type ID [Size]byte
+
+// Nil is the zero ID, encoded as "000000000000000000000000000".
+var Nil ID
+
+// sortable is base62.StdEncoding, its alphabet is in ascending ASCII order.
+var sortable = base62.StdEncoding.Alphabet()
+
+// Generator produces IDs strictly increasing,
+// even when generated within the same second.
+// A Generator is safe for concurrent use by multiple goroutines.
+type Generator struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	random io.Reader
+	last   ID
+}
+
+// NewGenerator returns a Generator reading the time from now
+// and the random payloads from random.
+// The nil arguments default to time.Now and crypto/rand.Reader.
+func NewGenerator(now func() time.Time, random io.Reader) *Generator {
+	if now == nil {
+		now = time.Now
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+	return &Generator{now: now, random: random}
+}
+
+var defaultGenerator = NewGenerator(nil, nil)
+
+// New returns a new ID from the default Generator
+// (using the system clock and crypto/rand).
+func New() (ID, error) {
+	return defaultGenerator.New()
+}
+
+// New returns a new ID.
+// The IDs generated within the same second (or when the clock goes backward)
+// are the previous ID incremented by one, so the IDs remain strictly increasing.
+func (g *Generator) New() (ID, error) {
+	var id ID
+
+	now := g.now()
+	sec := now.Unix()
+	if sec < 0 || sec > 1<<32-1 {
+		return Nil, fmt.Errorf("ids: time %v out of the 32-bit timestamp range", now)
+	}
+	binary.BigEndian.PutUint32(id[:timestampSize], uint32(sec))
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.last != Nil && id.Timestamp() <= g.last.Timestamp() {
+		id = g.last
+		if !id.increment() {
+			return Nil, fmt.Errorf("ids: no more ID after %v", g.last)
+		}
+	} else if _, err := io.ReadFull(g.random, id[timestampSize:]); err != nil {
+		return Nil, fmt.Errorf("ids: cannot read random payload: %w", err)
+	}
+
+	g.last = id
+	return id, nil
+}
+
+// increment adds one to the 160-bit value of id.
+// The payload overflow increments the timestamp.
+// It returns false when id is already the maximum value.
+func (id *ID) increment() bool {
+	for i := Size - 1; i >= 0; i-- {
+		id[i]++
+		if id[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse decodes a 27-character string into an ID.
+func Parse(str string) (ID, error) {
+	var id ID
+
+	if len(str) != Len {
+		return Nil, fmt.Errorf("ids: invalid length %d, want %d", len(str), Len)
+	}
+
+	bin, err := sortable.DecodeFixed(str)
+	if err != nil {
+		return Nil, fmt.Errorf("ids: %w", err)
+	}
+
+	copy(id[:], bin)
+	return id, nil
+}
+
+// String returns the 27-character Base62 representation of the ID.
+func (id ID) String() string {
+	return string(sortable.EncodeFixed(id[:]))
+}
+
+// Timestamp returns the number of seconds since the Unix epoch.
+func (id ID) Timestamp() uint32 {
+	return binary.BigEndian.Uint32(id[:timestampSize])
+}
+
+// Time returns the generation time of the ID, truncated to the second.
+func (id ID) Time() time.Time {
+	return time.Unix(int64(id.Timestamp()), 0)
+}
+
+// Payload returns the 128-bit part of the ID following the timestamp.
+func (id ID) Payload() []byte {
+	return id[timestampSize:]
+}
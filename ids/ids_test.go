@@ -0,0 +1,168 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package ids_test
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/teal-finance/BaseXX/ids"
+)
+
+func TestGenerator(t *testing.T) {
+	now := time.Unix(1_650_000_000, 0)
+	clock := func() time.Time { return now }
+	g := ids.NewGenerator(clock, rand.New(rand.NewSource(42)))
+
+	id1, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id1.Time().Equal(now) {
+		t.Errorf("Time() = %v, want %v", id1.Time(), now)
+	}
+
+	// same second: previous ID + 1
+	id2, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id2.Timestamp() != id1.Timestamp() || id2.String() <= id1.String() {
+		t.Errorf("New() = %v, want the successor of %v", id2, id1)
+	}
+	if id2.Payload()[15] != id1.Payload()[15]+1 && id2.Payload()[15] != 0 {
+		t.Errorf("Payload() = %x, want %x + 1", id2.Payload(), id1.Payload())
+	}
+
+	// clock going backward
+	now = now.Add(-time.Hour)
+	id3, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id3.String() <= id2.String() {
+		t.Errorf("New() = %v, want greater than %v", id3, id2)
+	}
+
+	// next second: new random payload
+	now = now.Add(2 * time.Hour)
+	id4, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id4.Time().Equal(now) || id4.String() <= id3.String() {
+		t.Errorf("New() = %v, want a new ID at %v", id4, now)
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	clock := func() time.Time { return time.Unix(1_650_000_000, 0) }
+	g1 := ids.NewGenerator(clock, rand.New(rand.NewSource(7)))
+	g2 := ids.NewGenerator(clock, rand.New(rand.NewSource(7)))
+
+	for i := 0; i < 3; i++ {
+		id1, _ := g1.New()
+		id2, _ := g2.New()
+		if id1 != id2 {
+			t.Errorf("#%d: %v != %v", i, id1, id2)
+		}
+	}
+}
+
+func TestGenerator_Overflow(t *testing.T) {
+	clock := func() time.Time { return time.Unix(1<<32-1, 0) }
+	g := ids.NewGenerator(clock, bytes.NewReader(bytes.Repeat([]byte{255}, 16)))
+
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := g.New(); err == nil {
+		t.Errorf("New() = %v, want an error after the maximum ID", id)
+	}
+
+	g = ids.NewGenerator(func() time.Time { return time.Unix(1<<32, 0) }, nil)
+	if id, err := g.New(); err == nil {
+		t.Errorf("New() = %v, want an error beyond the 32-bit timestamp", id)
+	}
+
+	g = ids.NewGenerator(nil, bytes.NewReader(nil))
+	if id, err := g.New(); err == nil {
+		t.Errorf("New() = %v, want an error on a short random source", id)
+	}
+}
+
+func TestGenerator_Concurrent(t *testing.T) {
+	const goroutines, perGoroutine = 16, 500
+	g := ids.NewGenerator(nil, nil)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[ids.ID]bool, goroutines*perGoroutine)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generated := make([]ids.ID, 0, perGoroutine)
+			for j := 0; j < perGoroutine; j++ {
+				id, err := g.New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				generated = append(generated, id)
+			}
+			// strictly increasing within each goroutine
+			if !sort.SliceIsSorted(generated, func(a, b int) bool {
+				return generated[a].String() < generated[b].String()
+			}) {
+				t.Error("IDs not in increasing order")
+			}
+			mu.Lock()
+			for _, id := range generated {
+				if seen[id] {
+					t.Errorf("duplicated ID %v", id)
+				}
+				seen[id] = true
+			}
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestParse(t *testing.T) {
+	g := ids.NewGenerator(nil, nil)
+
+	for i := 0; i < 100; i++ {
+		id, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		str := id.String()
+		if len(str) != ids.Len {
+			t.Fatalf("String() = %q, want %d characters", str, ids.Len)
+		}
+		got, err := ids.Parse(str)
+		if err != nil || got != id {
+			t.Fatalf("Parse(%q) = %v, %v, want %v", str, got, err, id)
+		}
+	}
+
+	if ids.Nil.String() != "000000000000000000000000000" {
+		t.Errorf("Nil.String() = %q", ids.Nil.String())
+	}
+
+	for _, str := range []string{"", "00000000000000000000000000", "zzzzzzzzzzzzzzzzzzzzzzzzzzz", "00000000000000000000000000-"} {
+		if id, err := ids.Parse(str); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", str, id)
+		}
+	}
+}
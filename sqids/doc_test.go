@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package sqids_test
+
+import (
+	"fmt"
+
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/sqids"
+)
+
+// Hide sequential database IDs in public URLs.
+func ExampleEncoding_Encode() {
+	enc := sqids.New(base58.StdEncoding).WithMinLength(8)
+
+	for id := uint64(1); id <= 3; id++ {
+		str, _ := enc.Encode(id)
+		fmt.Println(id, str)
+	}
+
+	str, _ := enc.Encode(42, 7)
+	numbers, err := enc.Decode(str)
+	fmt.Println(str, numbers, err)
+	// Output:
+	// 1 3QtvBKqR
+	// 2 MhstyJUd
+	// 3 UfgbNpjZ
+	// PFojUeAT [42 7] <nil>
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Package sqids turns lists of uint64 (such as sequential database IDs)
+// into short non-sequential strings and back, following the Sqids algorithm
+// (https://sqids.org) on the alphabet of any BaseXX encoding.
+// This is obfuscation, not encryption: do not use it to hide sensitive data.
+package sqids
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+// MaxMinLength is the maximum value of WithMinLength.
+const MaxMinLength = 255
+
+// Encoding converts lists of uint64 into strings.
+// The zero value is not usable, use New.
+type Encoding struct {
+	alphabet  []byte // shuffled
+	minLength int
+	blocklist []string
+}
+
+// New returns an Encoding using the alphabet of enc (e.g. base62.StdEncoding
+// or base58.NewEncoding(myAlphabet)) shuffled the Sqids way.
+// The Sqids default alphabet is:
+//
+//	base62.NewEncoding("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+//
+// New panics if the alphabet has less than 3 characters.
+func New(enc encoding.Encoder) *Encoding {
+	chars := enc.Alphabet().EncChars
+	if len(chars) < 3 {
+		log.Panicf("sqids: alphabet %q must have at least 3 characters", chars)
+	}
+
+	alphabet := make([]byte, len(chars))
+	copy(alphabet, chars)
+	shuffle(alphabet)
+
+	return &Encoding{alphabet: alphabet}
+}
+
+// WithMinLength returns a copy of the encoding padding the generated strings
+// to at least minLength characters.
+// It panics if minLength is outside [0..MaxMinLength].
+func (enc Encoding) WithMinLength(minLength int) *Encoding {
+	if minLength < 0 || minLength > MaxMinLength {
+		log.Panicf("sqids: minimum length %d must be in [0..%d]", minLength, MaxMinLength)
+	}
+	enc.minLength = minLength
+	return &enc
+}
+
+// WithBlocklist returns a copy of the encoding never generating a string
+// containing one of the words (case-insensitive). Following Sqids, the words
+// shorter than 3 characters or using characters outside the alphabet
+// are ignored, the words of 3 characters block only the exact same string,
+// and the words containing digits block only the prefixes and suffixes.
+func (enc Encoding) WithBlocklist(words ...string) *Encoding {
+	lowerAlphabet := strings.ToLower(string(enc.alphabet))
+
+	enc.blocklist = make([]string, 0, len(words))
+	for _, w := range words {
+		w = strings.ToLower(w)
+		if len(w) < 3 || strings.Trim(w, lowerAlphabet) != "" {
+			continue
+		}
+		enc.blocklist = append(enc.blocklist, w)
+	}
+
+	return &enc
+}
+
+// Encode converts numbers into a string, the empty string for no number.
+// The error occurs only when the blocklist rejects all the candidate strings.
+func (enc *Encoding) Encode(numbers ...uint64) (string, error) {
+	if len(numbers) == 0 {
+		return "", nil
+	}
+	return enc.encode(numbers, 0)
+}
+
+func (enc *Encoding) encode(numbers []uint64, increment int) (string, error) {
+	n := len(enc.alphabet)
+	if increment > n {
+		return "", fmt.Errorf("sqids: blocklist rejects all the %d attempts", n)
+	}
+
+	offset := len(numbers)
+	for i, v := range numbers {
+		offset += int(enc.alphabet[v%uint64(n)]) + i
+	}
+	offset = (offset%n + increment) % n
+
+	alphabet := make([]byte, 0, n)
+	alphabet = append(alphabet, enc.alphabet[offset:]...)
+	alphabet = append(alphabet, enc.alphabet[:offset]...)
+	prefix := alphabet[0]
+	reverse(alphabet)
+
+	id := make([]byte, 0, enc.minLength+1+len(numbers)*(64+1))
+	id = append(id, prefix)
+	for i, v := range numbers {
+		id = appendDigits(id, v, alphabet[1:])
+		if i < len(numbers)-1 {
+			id = append(id, alphabet[0]) // separator
+			shuffle(alphabet)
+		}
+	}
+
+	if len(id) < enc.minLength {
+		id = append(id, alphabet[0])
+		for len(id) < enc.minLength {
+			shuffle(alphabet)
+			missing := enc.minLength - len(id)
+			if missing > n {
+				missing = n
+			}
+			id = append(id, alphabet[:missing]...)
+		}
+	}
+
+	if enc.isBlocked(string(id)) {
+		return enc.encode(numbers, increment+1)
+	}
+
+	return string(id), nil
+}
+
+// Decode converts back a string generated by Encode.
+// Decode rejects the strings that Encode cannot produce
+// with the same configuration, so the decoding is exact:
+// an ID has only one string representation.
+func (enc *Encoding) Decode(str string) ([]uint64, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	for i := 0; i < len(str); i++ {
+		if indexByte(enc.alphabet, str[i]) < 0 {
+			return nil, fmt.Errorf("sqids: invalid character %q at position %d", str[i], i)
+		}
+	}
+
+	n := len(enc.alphabet)
+	offset := indexByte(enc.alphabet, str[0])
+
+	alphabet := make([]byte, 0, n)
+	alphabet = append(alphabet, enc.alphabet[offset:]...)
+	alphabet = append(alphabet, enc.alphabet[:offset]...)
+	reverse(alphabet)
+
+	var numbers []uint64
+	for rest := str[1:]; rest != ""; {
+		chunk, after, found := strings.Cut(rest, string(alphabet[0]))
+		if chunk == "" {
+			break // padding up to the minimum length
+		}
+
+		v, err := parseDigits(chunk, alphabet[1:])
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, v)
+
+		if found {
+			shuffle(alphabet)
+		}
+		rest = after
+	}
+
+	if canonical, err := enc.Encode(numbers...); err != nil || canonical != str {
+		return nil, fmt.Errorf("sqids: non-canonical string %q", str)
+	}
+
+	return numbers, nil
+}
+
+// appendDigits appends v converted into the radix len(digits),
+// with the same big-endian digits as the radix packages (see encoding.ConvertRadix),
+// except that zero is one zero digit.
+func appendDigits(dst []byte, v uint64, digits []byte) []byte {
+	var bin [8]byte
+	binary.BigEndian.PutUint64(bin[:], v)
+
+	values := encoding.ConvertRadix(encoding.TrimZeros(bin[:]), 256, len(digits))
+	if len(values) == 0 {
+		return append(dst, digits[0])
+	}
+
+	for _, d := range values {
+		dst = append(dst, digits[d])
+	}
+	return dst
+}
+
+// parseDigits converts back the digits appended by appendDigits.
+func parseDigits(str string, digits []byte) (uint64, error) {
+	values := make([]byte, len(str))
+	for i := 0; i < len(str); i++ {
+		values[i] = byte(indexByte(digits, str[i]))
+	}
+
+	// without the leading zero digits, ConvertRadix returns the minimal bytes
+	bin := encoding.ConvertRadix(encoding.TrimZeros(values), len(digits), 256)
+	if len(bin) > 8 {
+		return 0, fmt.Errorf("sqids: number %q exceeds 64 bits", str)
+	}
+
+	var v uint64
+	for _, b := range bin {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func (enc *Encoding) isBlocked(id string) bool {
+	id = strings.ToLower(id)
+
+	for _, w := range enc.blocklist {
+		switch {
+		case len(w) > len(id):
+			continue
+		case len(id) <= 3 || len(w) <= 3:
+			if id == w {
+				return true
+			}
+		case strings.ContainsAny(w, "0123456789"):
+			if strings.HasPrefix(id, w) || strings.HasSuffix(id, w) {
+				return true
+			}
+		case strings.Contains(id, w):
+			return true
+		}
+	}
+
+	return false
+}
+
+// shuffle is the consistent shuffle of the Sqids algorithm.
+func shuffle(chars []byte) {
+	n := len(chars)
+	for i, j := 0, n-1; j > 0; i, j = i+1, j-1 {
+		r := (i*j + int(chars[i]) + int(chars[j])) % n
+		chars[i], chars[r] = chars[r], chars[i]
+	}
+}
+
+func reverse(chars []byte) {
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+}
+
+func indexByte(chars []byte, c byte) int {
+	for i, x := range chars {
+		if x == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package sqids_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/teal-finance/BaseXX/base58"
+	"github.com/teal-finance/BaseXX/base62"
+	"github.com/teal-finance/BaseXX/sqids"
+)
+
+// sqidsAlphabet is the default alphabet of the Sqids reference implementations.
+var sqidsAlphabet = base62.NewEncoding("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+// The expected strings come from the Sqids specification tests.
+func TestEncode_Spec(t *testing.T) {
+	cases := []struct {
+		enc     *sqids.Encoding
+		numbers []uint64
+		want    string
+	}{
+		{sqids.New(sqidsAlphabet), []uint64{1, 2, 3}, "86Rf07"},
+		{sqids.New(sqidsAlphabet), []uint64{0}, "bM"},
+		{sqids.New(sqidsAlphabet).WithMinLength(10), []uint64{1, 2, 3}, "86Rf07xd4z"},
+		{sqids.New(sqidsAlphabet).WithBlocklist(), []uint64{4572721}, "aho1e"},
+		{sqids.New(sqidsAlphabet).WithBlocklist("ArUO"), []uint64{100000}, "QyG4"},
+		{
+			sqids.New(sqidsAlphabet).WithBlocklist("JSwXFaosAN", "OCjV9JK64o", "rBHf", "79SM", "7tE6"),
+			[]uint64{1_000_000, 2_000_000}, "1aYeB7bRUt",
+		},
+	}
+
+	for _, c := range cases {
+		got, err := c.enc.Encode(c.numbers...)
+		if err != nil || got != c.want {
+			t.Errorf("Encode(%v) = %q, %v, want %q", c.numbers, got, err, c.want)
+		}
+		numbers, err := c.enc.Decode(c.want)
+		if err != nil || !reflect.DeepEqual(numbers, c.numbers) {
+			t.Errorf("Decode(%q) = %v, %v, want %v", c.want, numbers, err, c.numbers)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	encodings := []*sqids.Encoding{
+		sqids.New(base58.StdEncoding),
+		sqids.New(base62.StdEncoding).WithMinLength(12),
+		sqids.New(base58.FlickrEncoding).WithBlocklist("abc", "1234", "xyz9"),
+		sqids.New(base62.NewEncoding("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")),
+	}
+
+	for _, enc := range encodings {
+		for i := 0; i < 200; i++ {
+			numbers := make([]uint64, 1+i%4)
+			for j := range numbers {
+				numbers[j] = rand.Uint64() >> (i % 64)
+			}
+			if i == 0 {
+				numbers = []uint64{0, math.MaxUint64}
+			}
+
+			str, err := enc.Encode(numbers...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := enc.Decode(str)
+			if err != nil || !reflect.DeepEqual(got, numbers) {
+				t.Fatalf("Decode(%q) = %v, %v, want %v", str, got, err, numbers)
+			}
+		}
+	}
+}
+
+func TestEncode_NonSequential(t *testing.T) {
+	enc := sqids.New(base62.StdEncoding).WithMinLength(8)
+
+	prev := ""
+	for id := uint64(1); id < 100; id++ {
+		str, err := enc.Encode(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(str) < 8 {
+			t.Errorf("Encode(%d) = %q, want at least 8 characters", id, str)
+		}
+		if str[:4] == prev[:min4(prev)] {
+			t.Errorf("Encode(%d) = %q too similar to %q", id, str, prev)
+		}
+		prev = str
+	}
+}
+
+func min4(s string) int {
+	if len(s) < 4 {
+		return len(s)
+	}
+	return 4
+}
+
+func TestBlocklist(t *testing.T) {
+	enc := sqids.New(sqidsAlphabet)
+	str, _ := enc.Encode(4572721) // "aho1e"
+
+	blocked := enc.WithBlocklist(strings.ToUpper(str[:4]))
+	got, err := blocked.Encode(4572721)
+	if err != nil || strings.Contains(strings.ToLower(got), str[:4]) {
+		t.Errorf("Encode() = %q, %v, want a string without %q", got, err, str[:4])
+	}
+
+	// the blocked string is not canonical for the new configuration
+	if numbers, err := blocked.Decode(str); err == nil {
+		t.Errorf("Decode(%q) = %v, want an error", str, numbers)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	enc := sqids.New(sqidsAlphabet)
+
+	for _, str := range []string{
+		"86Rf0-",                     // invalid character
+		"86Rf07x",                    // trailing padding without minimum length
+		"8aaaaaaaaaaaaaaaaaaaaaaaaa", // exceeds 64 bits
+	} {
+		if numbers, err := enc.Decode(str); err == nil {
+			t.Errorf("Decode(%q) = %v, want an error", str, numbers)
+		}
+	}
+
+	if numbers, err := enc.Decode(""); err != nil || len(numbers) != 0 {
+		t.Errorf("Decode(\"\") = %v, %v, want no number", numbers, err)
+	}
+}
+
+func TestNew_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on invalid minimum length did not occur")
+		}
+	}()
+	sqids.New(base62.StdEncoding).WithMinLength(sqids.MaxMinLength + 1)
+}
@@ -2,62 +2,572 @@
 // This file is part of Teal.Finance/BaseXX licensed under the MIT License.
 // SPDX-License-Identifier: MIT
 
-// Package xascii85 implements the standard Encoding interface
-// on top of "encoding/ascii85".
+// Package xascii85 implements the Ascii85 encoding (4 bytes into 5 characters)
+// with a configurable alphabet and the standard Encoding interface.
+//
+// StdEncoding produces the same output as "encoding/ascii85".
 package xascii85
 
 import (
 	"encoding/ascii85"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/teal-finance/BaseXX/encoding"
 )
 
-// Encoding is just an empty type
-// to provide the same inferface than "encoding/base64".
-type Encoding struct{}
+const Radix = 85
+
+// The Adobe/btoa alphabet: the 85 characters from '!' to 'u'.
+const alphabet = "!\"#$%&'()*+,-./0123456789:;<=>?@" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`" +
+	"abcdefghijklmnopqrstu"
+
+// StdEncoding is the standard Ascii85 encoding, as used by btoa and Adobe,
+// where "z" is the shortcut of four zero bytes.
+// Same as "encoding/ascii85".
+// StdEncoding is a *Encoding since the configurable alphabets:
+// the former versions exported the value Encoding{} (see Encoding).
+var StdEncoding = NewEncoding(alphabet).withZero('z')
+
+// AdobeEncoding is the Ascii85 encoding used by PDF and PostScript:
+// the encoded data is framed by the "<~" and "~>" delimiters.
+// When decoding, the "<~" delimiter is optional (as in PDF streams),
+// but the "~>" delimiter is required.
+var AdobeEncoding = StdEncoding.withFrame("<~", "~>")
+
+// BtoaEncoding is the Ascii85 encoding of the btoa 4.2 tool:
+// "z" is the shortcut of four zero bytes
+// and "y" is the shortcut of four spaces.
+var BtoaEncoding = StdEncoding.withSpaces('y')
+
+// ErrUnterminated is returned when decoding a framed encoding
+// (see AdobeEncoding) without the final delimiter.
+var ErrUnterminated = errors.New("xascii85: unterminated frame, missing final delimiter")
+
+// RFC1924Encoding uses the alphabet of RFC 1924
+// (without the IPv6 specific big-integer conversion).
+var RFC1924Encoding = NewEncoding("0123456789" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	"abcdefghijklmnopqrstuvwxyz" +
+	"!#$%&()*+-;<=>?@^_`{|}~")
+
+// CookieEncoding conforms with the cookie token constraints:
+// it excludes double-quote, semicolon and backslash.
+// Its alphabet is the Ascii85 one where these three characters
+// are replaced by "v", "w" and "x", keeping the ascending order.
+var CookieEncoding = NewEncoding("!#$%&'()*+,-./0123456789:<=>?@" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`" +
+	"abcdefghijklmnopqrstuvwx")
+
+// Encoding is an Ascii85 encoding with a configurable alphabet.
+// The zero value is StdEncoding, as in the former versions
+// where StdEncoding was the value Encoding{}.
+type Encoding struct {
+	alphabet *encoding.Encoding
+	zero     byte   // shortcut of four zero bytes, 0 if none
+	spaces   byte   // shortcut of four spaces, 0 if none
+	prefix   string // frame delimiters, empty if none
+	suffix   string
+}
+
+// NewEncoding returns a new Encoding using the given 85-character alphabet.
+// It panics if the alphabet does not contain 85 distinct ASCII characters.
+// The custom encodings have no shortcut for four zero bytes.
+func NewEncoding(encoder string) *Encoding {
+	return &Encoding{alphabet: encoding.NewEncoding(encoder, Radix)}
+}
+
+// std returns StdEncoding if enc is the zero value, else enc.
+func (enc *Encoding) std() *Encoding {
+	if enc.alphabet == nil {
+		return StdEncoding
+	}
+	return enc
+}
+
+func (enc Encoding) withZero(c byte) *Encoding {
+	enc.zero = c
+	return &enc
+}
+
+func (enc Encoding) withSpaces(c byte) *Encoding {
+	enc.spaces = c
+	return &enc
+}
+
+func (enc Encoding) withFrame(prefix, suffix string) *Encoding {
+	enc.prefix = prefix
+	enc.suffix = suffix
+	return &enc
+}
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+// It also panics if an alias is a shortcut character.
+func (enc Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	enc = *enc.std()
+	enc.alphabet = enc.alphabet.WithAliases(aliases)
+	enc.checkShortcuts()
+	return &enc
+}
+
+// CaseInsensitive returns a copy of the encoding
+// decoding the letters in both lower and upper cases.
+// See encoding.Encoding.CaseInsensitive:
+// it panics with the predefined alphabets
+// that contain letters in both cases.
+// It also panics if a shortcut character becomes an alias.
+func (enc Encoding) CaseInsensitive() *Encoding {
+	enc = *enc.std()
+	enc.alphabet = enc.alphabet.CaseInsensitive()
+	enc.checkShortcuts()
+	return &enc
+}
+
+// checkShortcuts panics if a shortcut character is decoded as a digit.
+func (enc *Encoding) checkShortcuts() {
+	for _, c := range []byte{enc.zero, enc.spaces} {
+		if c != 0 && enc.alphabet.DecMap[c] != -1 {
+			log.Panicf("Base%d: alias %q is a shortcut character", Radix, c)
+		}
+	}
+}
 
-// StdEncoding is an empty value just
-// to provide the same inferface than "encoding/base64".
-var StdEncoding = Encoding{}
+// WithGroups returns a copy of the encoding emitting the characters
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+// It also panics if separator contains a shortcut character.
+func (enc Encoding) WithGroups(size int, separator string) *Encoding {
+	enc = *enc.std()
+	enc.checkIgnore(separator)
+	enc.alphabet = enc.alphabet.WithGroups(size, separator)
+	return &enc
+}
 
-// NewEncoding creates a fake Encoding just
-// to provide the same inferface than "encoding/base64".
-func NewEncoding(_ string) *Encoding {
-	return &Encoding{}
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+// The whitespaces are always ignored when decoding.
+func (enc Encoding) WithWrap(width int) *Encoding {
+	enc = *enc.std()
+	enc.alphabet = enc.alphabet.WithWrap(width)
+	return &enc
 }
 
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+// It also panics if chars contains a shortcut character.
+func (enc Encoding) WithIgnore(chars string) *Encoding {
+	enc = *enc.std()
+	enc.checkIgnore(chars)
+	enc.alphabet = enc.alphabet.WithIgnore(chars)
+	return &enc
+}
+
+func (enc *Encoding) checkIgnore(chars string) {
+	for i := 0; i < len(chars); i++ {
+		if c := chars[i]; c != 0 && (c == enc.zero || c == enc.spaces) {
+			log.Panicf("Base%d: ignored character %q is a shortcut character", Radix, c)
+		}
+	}
+}
+
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding { return enc.std().alphabet }
+
+// Bytes is a byte slice appearing as an Ascii85 string (StdEncoding)
+// in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return StdEncoding }
+
+// Flag is a command-line parameter given as an Ascii85 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
 // Encode encodes binary bytes into Ascii85 bytes.
-func (Encoding) Encode(dst, src []byte) (n int) {
-	return ascii85.Encode(dst, src)
+// dst must have at least EncodedLen(len(src)) bytes.
+// Encode returns the number of written bytes:
+// a group of four zero bytes may be encoded into a single character,
+// and a trailing partial group of n bytes is encoded into n+1 characters.
+// The frame delimiters, the group separators and the line breaks,
+// if any, are included.
+func (enc *Encoding) Encode(dst, src []byte) (n int) {
+	enc = enc.std()
+	n = copy(dst, enc.prefix)
+	body := enc.encode(dst[n:], src)
+	n += enc.alphabet.Format(dst[n:], body)
+	n += copy(dst[n:], enc.suffix)
+	return n
+}
+
+func (enc *Encoding) encode(dst, src []byte) (n int) {
+	for len(src) > 0 {
+		var v uint32
+		switch len(src) {
+		default:
+			v |= uint32(src[3])
+			fallthrough
+		case 3:
+			v |= uint32(src[2]) << 8
+			fallthrough
+		case 2:
+			v |= uint32(src[1]) << 16
+			fallthrough
+		case 1:
+			v |= uint32(src[0]) << 24
+		}
+
+		// Special case: zero (!!!!!) shortens to z.
+		if v == 0 && len(src) >= 4 && enc.zero != 0 {
+			dst[n] = enc.zero
+			n++
+			src = src[4:]
+			continue
+		}
+
+		// Special case: four spaces shorten to y.
+		if v == 0x20202020 && len(src) >= 4 && enc.spaces != 0 {
+			dst[n] = enc.spaces
+			n++
+			src = src[4:]
+			continue
+		}
+
+		// Otherwise, 5 digits in base 85.
+		var chars [5]byte
+		for i := 4; i >= 0; i-- {
+			chars[i] = enc.alphabet.EncChars[v%Radix]
+			v /= Radix
+		}
+
+		// If src was short, discard the low destination bytes.
+		m := 5
+		if len(src) < 4 {
+			m -= 4 - len(src)
+			src = nil
+		} else {
+			src = src[4:]
+		}
+		n += copy(dst[n:], chars[:m])
+	}
+	return n
 }
 
 // Decode decodes Ascii85-encoded bytes into a slice of bytes.
-func (Encoding) Decode(dst, src []byte) (n int, err error) {
-	n, _, err = ascii85.Decode(dst, src, true)
+// dst must have at least DecodedLen(len(src)) bytes.
+// The whitespaces and control characters are ignored
+// (unless they belong to the alphabet).
+// The returned error is an ascii85.CorruptInputError
+// on invalid character or group overflow,
+// ErrUnterminated when the final frame delimiter is missing,
+// or io.ErrShortBuffer when dst is too short for the decoded data.
+// Decode is DecodePartial with flush set to true.
+func (enc *Encoding) Decode(dst, src []byte) (n int, err error) {
+	if dst == nil {
+		dst = []byte{} // no room, a nil dst would only count the decoded bytes
+	}
+	n, nsrc, err := decodeAll(enc, dst, src)
+	if err == nil && nsrc < len(src) {
+		err = io.ErrShortBuffer
+	}
 	return n, err
 }
 
+// decodeString decodes the whole s as Decode does,
+// this is the encoding.DecodeFunc of DecodeString, DecodeInto and Valid.
+func (enc *Encoding) decodeString(dst []byte, s string) (ndst, nsrc int, err error) {
+	return decodeAll(enc, dst, s)
+}
+
+// decodeAll is DecodePartial with flush set to true, also consuming
+// the whitespaces following the final frame delimiter:
+// it stops before the end of src only when dst is full.
+func decodeAll[T string | []byte](enc *Encoding, dst []byte, src T) (ndst, nsrc int, err error) {
+	ndst, nsrc, err = decodePartial(enc, dst, src, true)
+	if err != nil || enc.suffix == "" || !hasSuffix(src[:nsrc], enc.suffix) {
+		return ndst, nsrc, err
+	}
+
+	// only whitespaces are allowed after the final frame delimiter
+	for i := nsrc; i < len(src); i++ {
+		if src[i] > ' ' {
+			return ndst, nsrc, ascii85.CorruptInputError(i)
+		}
+	}
+
+	return ndst, len(src), nil
+}
+
+// DecodePartial decodes src into dst, returning both the number
+// of bytes written to dst and the number consumed from src,
+// as ascii85.Decode does.
+// It stops when dst has not enough room for the next group.
+//
+// If flush is false, the trailing partial group is not decoded (nor consumed):
+// the caller is expected to call again DecodePartial with the
+// remaining input followed by the next chunk of input.
+// If flush is true, src is the end of the input stream:
+// the trailing partial group is decoded.
+//
+// For the framed encodings (see AdobeEncoding),
+// DecodePartial stops after the final frame delimiter:
+// nsrc is the offset following "~>".
+//
+// On error, ndst and nsrc report the data decoded so far.
+func (enc *Encoding) DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error) {
+	if dst == nil {
+		dst = []byte{} // no room, a nil dst would only count the decoded bytes
+	}
+	return decodePartial(enc, dst, src, flush)
+}
+
+// decodePartial is DecodePartial for both strings and byte slices.
+// A nil dst only validates src: ndst counts the decoded bytes.
+func decodePartial[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	enc = enc.std()
+	if enc.suffix == "" {
+		return decodeGroups(enc, dst, src, flush)
+	}
+
+	start := 0
+	for start < len(src) && src[start] <= ' ' {
+		start++
+	}
+
+	rest := src[start:]
+	switch {
+	case hasPrefix(rest, enc.prefix):
+		start += len(enc.prefix)
+	case !flush && len(rest) < len(enc.prefix) && hasPrefix(rest, enc.prefix[:len(rest)]):
+		return 0, 0, nil // wait for the rest of the initial delimiter
+	}
+
+	end := index(src[start:], enc.suffix)
+	if end < 0 {
+		if flush {
+			return 0, 0, ErrUnterminated
+		}
+
+		// keep a possible beginning of the final delimiter for the next call
+		body := src[start:]
+		for i := len(enc.suffix) - 1; i > 0; i-- {
+			if hasSuffix(body, enc.suffix[:i]) {
+				body = body[:len(body)-i]
+				break
+			}
+		}
+
+		ndst, nsrc, err = decodeGroups(enc, dst, body, false)
+		if nsrc > 0 || err != nil {
+			nsrc += start
+		}
+		return ndst, nsrc, shift(err, start)
+	}
+
+	body := src[start : start+end]
+	ndst, nsrc, err = decodeGroups(enc, dst, body, true)
+	if err != nil {
+		return ndst, start + nsrc, shift(err, start)
+	}
+	if nsrc < len(body) {
+		return ndst, start + nsrc, nil // dst is full
+	}
+
+	return ndst, start + end + len(enc.suffix), nil
+}
+
+// hasPrefix is bytes.HasPrefix and strings.HasPrefix.
+func hasPrefix[T string | []byte](s T, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasSuffix is bytes.HasSuffix and strings.HasSuffix.
+func hasSuffix[T string | []byte](s T, suffix string) bool {
+	return len(s) >= len(suffix) && hasPrefix(s[len(s)-len(suffix):], suffix)
+}
+
+// index is bytes.Index and strings.Index for the short frame delimiters.
+func index[T string | []byte](s T, sep string) int {
+	for i := 0; i+len(sep) <= len(s); i++ {
+		if hasPrefix(s[i:], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+// shift adds offset to the position reported by a CorruptInputError.
+func shift(err error, offset int) error {
+	var e ascii85.CorruptInputError
+	if errors.As(err, &e) {
+		return e + ascii85.CorruptInputError(offset)
+	}
+	return err
+}
+
+// decodeGroups is decodePartial without the frame delimiters.
+func decodeGroups[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	var v uint64
+	var nb int
+
+	for i := 0; i < len(src); i++ {
+		b := src[i]
+		var d int8 = -1
+		if b < 128 {
+			d = enc.alphabet.DecMap[b]
+		}
+
+		switch {
+		case d >= 0:
+			v = v*Radix + uint64(d)
+			nb++
+		case b == enc.zero && b != 0 && nb == 0:
+			nb = 5
+			v = 0
+		case b == enc.spaces && b != 0 && nb == 0:
+			nb = 5
+			v = 0x20202020
+		case b <= ' ' || enc.alphabet.Ignored(b):
+			continue
+		default:
+			return ndst, nsrc, ascii85.CorruptInputError(i)
+		}
+
+		// Number of bytes complete.
+		if nb == 5 {
+			if dst != nil && len(dst)-ndst < 4 {
+				return ndst, nsrc, nil
+			}
+			if v > 0xffffffff {
+				return ndst, nsrc, ascii85.CorruptInputError(i)
+			}
+			nsrc = i + 1
+			if dst != nil {
+				dst[ndst] = byte(v >> 24)
+				dst[ndst+1] = byte(v >> 16)
+				dst[ndst+2] = byte(v >> 8)
+				dst[ndst+3] = byte(v)
+			}
+			ndst += 4
+			nb = 0
+			v = 0
+		}
+	}
+
+	if !flush {
+		return ndst, nsrc, nil
+	}
+
+	if nb > 0 {
+		// The number of output bytes in the last fragment
+		// is the number of leftover input bytes - 1:
+		// the extra byte provides enough bits to cover
+		// the inefficiency of the encoding for the block.
+		if dst != nil && (len(dst) == ndst || len(dst)-ndst < nb-1) {
+			return ndst, nsrc, nil // no room for the trailing partial group
+		}
+		if nb == 1 {
+			return ndst, nsrc, ascii85.CorruptInputError(len(src))
+		}
+		for i := nb; i < 5; i++ {
+			// The short encoding truncated the output value.
+			// We have to assume the worst case values (digit 84)
+			// in order to ensure that the top bits are correct.
+			v = v*Radix + Radix - 1
+		}
+		if v > 0xffffffff {
+			return ndst, nsrc, ascii85.CorruptInputError(len(src))
+		}
+		if dst == nil {
+			return ndst + nb - 1, len(src), nil
+		}
+		for i := 0; i < nb-1; i++ {
+			dst[ndst] = byte(v >> 24)
+			v <<= 8
+			ndst++
+		}
+	}
+
+	return ndst, len(src), nil
+}
+
 // EncodeToString encodes binary bytes into an Ascii85 string
 // allocating the destination buffer at the right size.
-func (Encoding) EncodeToString(src []byte) string {
-	max := ascii85.MaxEncodedLen(len(src))
-	dst := make([]byte, max)
-	n := ascii85.Encode(dst, src)
+func (enc *Encoding) EncodeToString(src []byte) string {
+	dst := make([]byte, enc.EncodedLen(len(src)))
+	n := enc.Encode(dst, src)
 	return string(dst[:n])
 }
 
 // DecodeString decodes an Ascii85 string into a slice of bytes
 // allocating the destination buffer at the right size.
-func (enc Encoding) DecodeString(s string) ([]byte, error) {
-	src := []byte(s)
-	max := enc.DecodedLen(len(src))
-	dst := make([]byte, max)
-	n, _, err := ascii85.Decode(dst, src, true)
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(s)))
+	n, nsrc, err := enc.decodeString(dst, s)
+	if err == nil && nsrc < len(s) {
+		err = io.ErrShortBuffer
+	}
 	return dst[:n], err
 }
 
-// EncodedLen returns the maximum length in bytes required to encode n bytes.
-func (Encoding) EncodedLen(n int) int { return ascii85.MaxEncodedLen(n) }
+// DecodeBytes returns the bytes represented by the Ascii85 bytes src,
+// allocating the destination buffer at the right size.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(dst, src)
+	return dst[:n], err
+}
+
+// DecodeInto decodes the Ascii85 string s into dst,
+// requiring exactly len(dst) decoded bytes, else the error is an *encoding.LengthError.
+// The content of dst is unspecified when an error occurs.
+func (enc *Encoding) DecodeInto(dst []byte, s string) error {
+	return encoding.DecodeBlocksInto(enc.decodeString, Radix, dst, s)
+}
+
+// Valid reports whether s is a valid Ascii85 string,
+// without allocating memory (see encoding.ValidBlocks).
+func (enc *Encoding) Valid(s string) bool {
+	_, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok
+}
+
+// ValidFor reports whether s is a valid Ascii85 string
+// decoding into at most n bytes, without allocating memory (see Valid).
+func (enc *Encoding) ValidFor(s string, n int) bool {
+	size, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok && size <= n
+}
+
+// EncodedLen returns the maximum length in bytes required to encode n bytes,
+// including the frame delimiters, the group separators and the line breaks, if any.
+func (enc *Encoding) EncodedLen(n int) int {
+	enc = enc.std()
+	return len(enc.prefix) + enc.alphabet.FormattedLen((n+3)/4*5) + len(enc.suffix)
+}
 
 // DecodedLen returns the maximum length in bytes
 // required to decode n Ascii85-encoded bytes.
 // Ascii85 decodes 4 bytes 0x0000 from only one byte "z".
-func (Encoding) DecodedLen(n int) int { return 4 * n }
+func (enc *Encoding) DecodedLen(n int) int {
+	enc = enc.std()
+	if enc.zero != 0 || enc.spaces != 0 {
+		return 4 * n
+	}
+	return (n + 4) / 5 * 4
+}
@@ -5,7 +5,13 @@
 package xascii85
 
 import (
+	"bytes"
+	"encoding/ascii85"
+	"errors"
+	"io"
+	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -65,3 +71,396 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestStdEncoding_SameAsStdlib(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+		if i%5 == 0 && len(bin) > 8 {
+			copy(bin[4:8], []byte{0, 0, 0, 0}) // exercise the "z" shortcut
+		}
+
+		want := make([]byte, ascii85.MaxEncodedLen(len(bin)))
+		want = want[:ascii85.Encode(want, bin)]
+
+		got := StdEncoding.EncodeToString(bin)
+		if got != string(want) {
+			t.Fatalf("EncodeToString(%x) = %q, want %q", bin, got, want)
+		}
+
+		dec, err := StdEncoding.DecodeString(got)
+		if err != nil || !bytes.Equal(dec, bin) {
+			t.Fatalf("DecodeString(%q) = %x, %v, want %x", got, dec, err, bin)
+		}
+	}
+}
+
+func TestEncoding_ZeroValue(t *testing.T) {
+	var enc Encoding
+	for _, c := range cases {
+		want := StdEncoding.EncodeToString(c.bin)
+		if got := enc.EncodeToString(c.bin); got != want {
+			t.Errorf("%s: EncodeToString() = %q, want %q", c.name, got, want)
+		}
+		got, err := enc.DecodeString(want)
+		if err != nil || !bytes.Equal(got, c.bin) {
+			t.Errorf("%s: DecodeString(%q) = %x, %v, want %x", c.name, want, got, err, c.bin)
+		}
+	}
+
+	if got := enc.WithWrap(8).EncodeToString(make([]byte, 40)); got != "zzzzzzzz\nzz" {
+		t.Errorf("WithWrap(8).EncodeToString() = %q, want %q", got, "zzzzzzzz\nzz")
+	}
+	if enc.Alphabet() != StdEncoding.Alphabet() {
+		t.Error("Alphabet() is not the StdEncoding one")
+	}
+}
+
+func TestCustomEncodings(t *testing.T) {
+	encodings := map[string]*Encoding{
+		"RFC1924": RFC1924Encoding,
+		"Cookie":  CookieEncoding,
+		"Z85":     NewEncoding("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"),
+	}
+
+	for name, enc := range encodings {
+		for i := 0; i < 500; i++ {
+			bin := make([]byte, rand.Intn(50))
+			rand.Read(bin)
+			if i%5 == 0 && len(bin) > 8 {
+				copy(bin[4:8], []byte{0, 0, 0, 0}) // no "z" shortcut
+			}
+
+			str := enc.EncodeToString(bin)
+			if len(str) > enc.EncodedLen(len(bin)) {
+				t.Fatalf("%s: EncodedLen(%d) = %d but got %d characters", name, len(bin), enc.EncodedLen(len(bin)), len(str))
+			}
+
+			got, err := enc.DecodeString(str)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("%s: DecodeString(%q) = %x, %v, want %x", name, str, got, err, bin)
+			}
+		}
+	}
+}
+
+func TestCookieEncoding(t *testing.T) {
+	chars := CookieEncoding.Alphabet().EncChars
+	for i, c := range chars {
+		if c < 0x20 || c > 0x7E || c == '"' || c == ';' || c == '\\' {
+			t.Errorf("forbidden cookie character %q", c)
+		}
+		if i > 0 && chars[i-1] >= c {
+			t.Errorf("alphabet not in ascending order at %q", c)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	cases := []struct {
+		str    string
+		offset ascii85.CorruptInputError
+	}{
+		{"87cURD{", 6},   // "{" is not in the alphabet
+		{"87cUz", 4},     // 'z' inside a group
+		{"87cURD", 6},    // a trailing single character cannot be decoded
+		{"s8W-\"", 4},    // 0xFFFFFFFF is "s8W-!", "s8W-\"" overflows
+		{"87cUR\xff", 5}, // non-ASCII
+	}
+
+	for _, c := range cases {
+		_, err := StdEncoding.DecodeString(c.str)
+		var e ascii85.CorruptInputError
+		if !errors.As(err, &e) || e != c.offset {
+			t.Errorf("DecodeString(%q) error = %v, want CorruptInputError(%d)", c.str, err, c.offset)
+		}
+	}
+
+	// whitespaces are ignored
+	got, err := StdEncoding.DecodeString(" 87c\tUR\r\nz\n")
+	if err != nil || !bytes.Equal(got, []byte("Hell\x00\x00\x00\x00")) {
+		t.Errorf("DecodeString() = %q, %v", got, err)
+	}
+}
+
+func TestDecode_ShortBuffer(t *testing.T) {
+	cases := []struct {
+		enc *Encoding
+		str string
+		n   int // size of dst
+	}{
+		{StdEncoding, "87cURD]i,\"Ebo80", 0},
+		{StdEncoding, "87cURD]i,\"Ebo80", 2},
+		{StdEncoding, "87cURD]i,\"Ebo80", 4},
+		{StdEncoding, "zz", 4},
+		{StdEncoding, "87cURDZ", 4}, // no room for the trailing partial group
+		{AdobeEncoding, "<~87cURD]i,\"Ebo80~>", 4},
+	}
+
+	for _, c := range cases {
+		_, err := c.enc.Decode(make([]byte, c.n), []byte(c.str))
+		if !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("Decode(%d bytes, %q) error = %v, want io.ErrShortBuffer", c.n, c.str, err)
+		}
+	}
+
+	// trailing whitespaces are not an overflow of dst
+	for _, enc := range []*Encoding{StdEncoding, AdobeEncoding} {
+		str := enc.EncodeToString([]byte("HelloWorld")) + " \n"
+		if n, err := enc.Decode(make([]byte, 10), []byte(str)); n != 10 || err != nil {
+			t.Errorf("Decode(%q) = %d, %v, want 10, nil", str, n, err)
+		}
+	}
+}
+
+func TestAdobeEncoding(t *testing.T) {
+	if got := AdobeEncoding.EncodeToString([]byte("Hello")); got != "<~87cURDZ~>" {
+		t.Errorf("EncodeToString(Hello) = %q, want <~87cURDZ~>", got)
+	}
+
+	cases := []struct {
+		str  string
+		want string
+		err  error
+	}{
+		{"<~87cURDZ~>", "Hello", nil},
+		{"  <~87cU\r\nRD Z~>\n", "Hello", nil},
+		{"87cURDZ~>", "Hello", nil}, // "<~" is optional
+		{"<~~>", "", nil},
+		{"<~z~>", "\x00\x00\x00\x00", nil},
+		{"<~87cURDZ", "", ErrUnterminated},
+		{"<~87cURDZ~", "", ErrUnterminated},
+		{"", "", ErrUnterminated},
+		{"<~87cURDZ~>x", "", ascii85.CorruptInputError(11)},
+		{"<~87{URDZ~>", "", ascii85.CorruptInputError(4)},
+	}
+
+	for _, c := range cases {
+		got, err := AdobeEncoding.DecodeString(c.str)
+		if !errors.Is(err, c.err) {
+			t.Errorf("DecodeString(%q) error = %v, want %v", c.str, err, c.err)
+			continue
+		}
+		if err == nil && string(got) != c.want {
+			t.Errorf("DecodeString(%q) = %q, want %q", c.str, got, c.want)
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+		str := AdobeEncoding.EncodeToString(bin)
+		got, err := AdobeEncoding.DecodeString(str)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("DecodeString(%q) = %x, %v, want %x", str, got, err, bin)
+		}
+	}
+}
+
+func TestBtoaEncoding(t *testing.T) {
+	bin := []byte("\x00\x00\x00\x00    Hello    ")
+
+	str := BtoaEncoding.EncodeToString(bin)
+	if str != "zy87cURD]g/F+9" {
+		t.Errorf("EncodeToString(%q) = %q", bin, str)
+	}
+
+	got, err := BtoaEncoding.DecodeString(str)
+	if err != nil || !bytes.Equal(got, bin) {
+		t.Errorf("DecodeString(%q) = %q, %v", str, got, err)
+	}
+
+	if _, err := StdEncoding.DecodeString("y"); err == nil {
+		t.Error("StdEncoding must not accept the y shortcut")
+	}
+	if _, err := BtoaEncoding.DecodeString("87y"); err == nil {
+		t.Error("the y shortcut must not be accepted inside a group")
+	}
+}
+
+// decodeChunks decodes str by feeding DecodePartial with chunks of the given size,
+// as a protocol parser does when the bytes arrive from the network.
+func decodeChunks(enc *Encoding, str string, size int) ([]byte, error) {
+	var out []byte
+	var pending []byte
+	dst := make([]byte, 64)
+
+	for i := 0; i < len(str) || len(pending) > 0; i += size {
+		end := i + size
+		if end > len(str) {
+			end = len(str)
+		}
+		if i < len(str) {
+			pending = append(pending, str[i:end]...)
+		}
+		flush := end == len(str)
+
+		for {
+			ndst, nsrc, err := enc.DecodePartial(dst, pending, flush)
+			out = append(out, dst[:ndst]...)
+			if err != nil {
+				return out, err
+			}
+			if enc.suffix != "" && bytes.HasSuffix(pending[:nsrc], []byte(enc.suffix)) {
+				return out, nil // end of frame, ignore the following bytes
+			}
+			pending = pending[nsrc:]
+			if ndst == 0 && nsrc == 0 {
+				break
+			}
+		}
+
+		if flush {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func TestDecodePartial(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		bin := make([]byte, rand.Intn(200))
+		rand.Read(bin)
+		if i%5 == 0 && len(bin) > 8 {
+			copy(bin[4:8], []byte{0, 0, 0, 0}) // exercise the "z" shortcut
+		}
+		size := 1 + rand.Intn(12)
+
+		for name, enc := range map[string]*Encoding{"std": StdEncoding, "adobe": AdobeEncoding, "btoa": BtoaEncoding, "cookie": CookieEncoding} {
+			str := enc.EncodeToString(bin)
+			if name == "adobe" {
+				cut := len(str) - 2 // insert a newline in the middle of the data
+				if cut > 7 {
+					cut = 7
+				}
+				str = "\n" + str[:cut] + "\n" + str[cut:] + " \n"
+			}
+
+			got, err := decodeChunks(enc, str, size)
+			if err != nil || !bytes.Equal(got, bin) {
+				t.Fatalf("%s: chunks of %d: decode(%q) = %x, %v, want %x", name, size, str, got, err, bin)
+			}
+		}
+	}
+}
+
+func TestDecodePartial_SameAsStdlib(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+		str := []byte(StdEncoding.EncodeToString(bin))
+		cut := rand.Intn(len(str) + 1)
+		flush := i%2 == 0
+
+		// the standard library stops as soon as dst has less than 4 bytes left,
+		// even when the remaining input is a trailing partial group
+		dst1 := make([]byte, 4*rand.Intn(len(bin)/4+2))
+		dst2 := make([]byte, len(dst1))
+		ndst1, nsrc1, err1 := StdEncoding.DecodePartial(dst1, str[:cut], flush)
+		ndst2, nsrc2, err2 := ascii85.Decode(dst2, str[:cut], flush)
+
+		// on error, the standard library reports nothing decoded
+		// whereas DecodePartial reports the groups decoded before the error
+		if err1 != err2 || (err1 == nil && (ndst1 != ndst2 || nsrc1 != nsrc2 || !bytes.Equal(dst1, dst2))) {
+			t.Fatalf("DecodePartial(%q, flush=%v) = %d, %d, %v but ascii85.Decode = %d, %d, %v",
+				str[:cut], flush, ndst1, nsrc1, err1, ndst2, nsrc2, err2)
+		}
+	}
+}
+
+func TestDecodePartial_Unterminated(t *testing.T) {
+	dst := make([]byte, 10)
+
+	ndst, nsrc, err := AdobeEncoding.DecodePartial(dst, []byte("<~87cURDZ~"), false)
+	if err != nil || ndst != 4 || nsrc != 7 {
+		t.Errorf("DecodePartial() = %d, %d, %v, want 4, 7, nil", ndst, nsrc, err)
+	}
+
+	_, _, err = AdobeEncoding.DecodePartial(dst, []byte("<~87cURDZ~"), true)
+	if !errors.Is(err, ErrUnterminated) {
+		t.Errorf("DecodePartial() error = %v, want ErrUnterminated", err)
+	}
+
+	ndst, nsrc, err = AdobeEncoding.DecodePartial(dst, []byte("<~87cURDZ~>garbage"), false)
+	if err != nil || ndst != 5 || nsrc != 11 {
+		t.Errorf("DecodePartial() = %d, %d, %v, want 5, 11, nil", ndst, nsrc, err)
+	}
+}
+
+func TestWithGroups(t *testing.T) {
+	enc := AdobeEncoding.WithGroups(5, "|").WithWrap(8)
+
+	str := enc.EncodeToString([]byte("Hello, World"))
+	if want := "<~87cUR|D_\n*#4|DfTZ\n)~>"; str != want {
+		t.Errorf("EncodeToString() = %q, want %q", str, want)
+	}
+
+	got, err := enc.DecodeString(str)
+	if err != nil || string(got) != "Hello, World" {
+		t.Errorf("DecodeString(%q) = %q, %v, want %q", str, got, err, "Hello, World")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on ignored shortcut character did not occur")
+		}
+	}()
+	StdEncoding.WithIgnore("z")
+}
+
+func TestWithAliases(t *testing.T) {
+	enc := StdEncoding.WithAliases(map[byte]byte{'v': '!', 'w': 'u'})
+
+	for _, str := range []string{"s8W-!!!!!u", "s8W-vvvvvw"} {
+		got, err := enc.DecodeString(str)
+		if err != nil || !bytes.Equal(got, []byte{255, 255, 255, 255, 0, 0, 0, 84}) {
+			t.Errorf("DecodeString(%q) = %v, %v, want [255 255 255 255 0 0 0 84]", str, got, err)
+		}
+	}
+	if got := enc.EncodeToString([]byte{0, 0, 0, 0}); got != "z" {
+		t.Errorf("EncodeToString() = %q, want z", got)
+	}
+
+	for _, enc := range []*Encoding{StdEncoding, BtoaEncoding} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic on alias being a shortcut character did not occur")
+				}
+			}()
+			enc.WithAliases(map[byte]byte{'z': '!', 'y': '!'})
+		}()
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	// 85 characters without lower-case letters: the control characters are required
+	var alphabet []byte
+	for c := byte(1); len(alphabet) < Radix; c++ {
+		alphabet = append(alphabet, c)
+	}
+	enc := NewEncoding(string(alphabet)).CaseInsensitive()
+
+	for i := 0; i < 100; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+
+		str := strings.ToLower(enc.EncodeToString(bin))
+		got, err := enc.DecodeString(str)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("DecodeString(%q) = %x, %v, want %x", str, got, err, bin)
+		}
+	}
+
+	for _, enc := range []*Encoding{StdEncoding, RFC1924Encoding, CookieEncoding} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic on alphabet having both letter cases did not occur")
+				}
+			}()
+			enc.CaseInsensitive()
+		}()
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+package z85_test
+
+import (
+	"fmt"
+
+	"github.com/teal-finance/BaseXX/z85"
+)
+
+// Encode binary data having a length multiple of 4.
+func ExampleEncoding_EncodeToString() {
+	bin := []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}
+
+	str := z85.StdEncoding.EncodeToString(bin)
+
+	fmt.Println("Z85 string:", str)
+	// Output:
+	// Z85 string: HelloWorld
+}
+
+// Encode and decode binary data of any length.
+func ExampleEncoding_DecodeString() {
+	bin := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 254, 255, 42}
+
+	str := z85.PaddedEncoding.EncodeToString(bin)
+	bin, err := z85.PaddedEncoding.DecodeString(str)
+
+	fmt.Println("Binary:", bin)
+	fmt.Println("Z85:   ", str)
+	fmt.Println("Error: ", err)
+	// Output:
+	// Binary: [0 1 2 3 4 5 6 7 8 9 254 255 42]
+	// Z85:    009c61o!#m2NQIgdG
+	// Error:  <nil>
+}
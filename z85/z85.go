@@ -0,0 +1,343 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+// Package z85 implements the Z85 encoding specified by ZeroMQ RFC 32
+// (https://rfc.zeromq.org/spec/32/) with the same API as xascii85.
+//
+// Z85 encodes 4 bytes into 5 characters using an alphabet
+// without double-quote, semicolon and backslash:
+// the Z85 strings can be used in cookies, JSON and source code.
+//
+// The specification requires input lengths multiple of 4.
+// PaddedEncoding relaxes this constraint:
+// a trailing partial block of n bytes is encoded into n+1 characters,
+// like Ascii85 does.
+package z85
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/teal-finance/BaseXX/encoding"
+)
+
+const Radix = 85
+
+const alphabet = "0123456789" +
+	"abcdefghijklmnopqrstuvwxyz" +
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	".-:+=^!/*?&<>()[]{}@%$#"
+
+// StdEncoding is the Z85 encoding as specified by ZeroMQ RFC 32:
+// the length of the binary data must be a multiple of 4
+// and the length of the encoded data must be a multiple of 5.
+var StdEncoding = NewEncoding(alphabet)
+
+// PaddedEncoding is the Z85 encoding accepting any input length.
+// The result is the same as StdEncoding when the length is a multiple of 4.
+var PaddedEncoding = StdEncoding.WithPadding()
+
+// Encoding is a Z85 encoding with a configurable alphabet.
+type Encoding struct {
+	alphabet *encoding.Encoding
+	padding  bool
+}
+
+// NewEncoding returns a new strict Encoding using the given 85-character alphabet.
+// It panics if the alphabet does not contain 85 distinct ASCII characters.
+func NewEncoding(encoder string) *Encoding {
+	return &Encoding{alphabet: encoding.NewEncoding(encoder, Radix)}
+}
+
+// WithPadding returns a copy of the encoding accepting input lengths
+// that are not a multiple of 4 (when encoding) or 5 (when decoding).
+func (enc Encoding) WithPadding() *Encoding {
+	enc.padding = true
+	return &enc
+}
+
+// WithAliases returns a copy of the encoding decoding each key of aliases
+// as the digit given by its value. See encoding.Encoding.WithAliases.
+// The aliases may only be the characters excluded from the Z85 alphabet,
+// e.g. {'~': '.'}.
+func (enc Encoding) WithAliases(aliases map[byte]byte) *Encoding {
+	enc.alphabet = enc.alphabet.WithAliases(aliases)
+	return &enc
+}
+
+// CaseInsensitive returns a copy of the encoding
+// decoding the letters in both lower and upper cases.
+// See encoding.Encoding.CaseInsensitive:
+// it panics with the standard alphabet
+// that contains the letters in both cases.
+func (enc Encoding) CaseInsensitive() *Encoding {
+	enc.alphabet = enc.alphabet.CaseInsensitive()
+	return &enc
+}
+
+// WithGroups returns a copy of the encoding emitting the characters
+// in groups of size characters joined by separator.
+// See encoding.Encoding.WithGroups.
+func (enc Encoding) WithGroups(size int, separator string) *Encoding {
+	enc.alphabet = enc.alphabet.WithGroups(size, separator)
+	return &enc
+}
+
+// WithWrap returns a copy of the encoding breaking the output
+// into lines of width characters. See encoding.Encoding.WithWrap.
+func (enc Encoding) WithWrap(width int) *Encoding {
+	enc.alphabet = enc.alphabet.WithWrap(width)
+	return &enc
+}
+
+// WithIgnore returns a copy of the encoding skipping
+// the given characters when decoding. See encoding.Encoding.WithIgnore.
+func (enc Encoding) WithIgnore(chars string) *Encoding {
+	enc.alphabet = enc.alphabet.WithIgnore(chars)
+	return &enc
+}
+
+// Alphabet returns the underlying encoding.Encoding
+// to be used with the functions of the encoding package.
+func (enc *Encoding) Alphabet() *encoding.Encoding { return enc.alphabet }
+
+// CorruptInputError reports the offset of an invalid Z85 character or block.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return "illegal z85 data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// Bytes is a byte slice appearing as a Z85 string (PaddedEncoding,
+// accepting any length) in the text formats, in JSON and in the database text columns.
+// See encoding.Text to use another alphabet.
+type Bytes = encoding.Text[stdFormat]
+
+type stdFormat struct{}
+
+func (stdFormat) Codec() encoding.Codec { return PaddedEncoding }
+
+// Flag is a command-line parameter given as a Z85 string
+// (see Bytes for the encoding), implementing flag.Value.
+// Set Size to require an exact number of bytes. See encoding.Flag.
+type Flag = encoding.Flag[stdFormat]
+
+// Encode encodes src into EncodedLen(len(src)) bytes of dst
+// and returns the number of written bytes,
+// including the group separators and the line breaks, if any.
+//
+// With StdEncoding, Encode panics if len(src) is not a multiple of 4,
+// as the reference implementation refuses such input.
+// Use PaddedEncoding to encode data of any length.
+func (enc *Encoding) Encode(dst, src []byte) int {
+	if !enc.padding && len(src)%4 != 0 {
+		panic(fmt.Sprintf("z85: input length %d is not a multiple of 4, use PaddedEncoding", len(src)))
+	}
+
+	n := 0
+	for len(src) >= 4 {
+		enc.encodeBlock(dst[n:n+5], uint32(src[0])<<24|uint32(src[1])<<16|uint32(src[2])<<8|uint32(src[3]))
+		src = src[4:]
+		n += 5
+	}
+
+	if len(src) > 0 {
+		var block [4]byte
+		copy(block[:], src)
+		var chars [5]byte
+		enc.encodeBlock(chars[:], uint32(block[0])<<24|uint32(block[1])<<16|uint32(block[2])<<8|uint32(block[3]))
+		n += copy(dst[n:], chars[:len(src)+1])
+	}
+
+	return enc.alphabet.Format(dst, n)
+}
+
+func (enc *Encoding) encodeBlock(dst []byte, v uint32) {
+	for i := 4; i >= 0; i-- {
+		dst[i] = enc.alphabet.EncChars[v%Radix]
+		v /= Radix
+	}
+}
+
+// Decode decodes src into DecodedLen(len(src)) bytes of dst
+// and returns the number of written bytes.
+// The returned error is a CorruptInputError
+// on invalid character, block overflow or invalid length,
+// or io.ErrShortBuffer when dst is too short for the decoded data.
+// Decode is DecodePartial with flush set to true.
+func (enc *Encoding) Decode(dst, src []byte) (int, error) {
+	n, nsrc, err := enc.DecodePartial(dst, src, true)
+	if err == nil && nsrc < len(src) {
+		err = io.ErrShortBuffer
+	}
+	return n, err
+}
+
+// DecodePartial decodes src into dst, returning both the number
+// of bytes written to dst and the number consumed from src,
+// as ascii85.Decode does.
+// It stops when dst has not enough room for the next block.
+//
+// If flush is false, the trailing partial block is not decoded (nor consumed):
+// the caller is expected to call again DecodePartial with the
+// remaining input followed by the next chunk of input.
+// If flush is true, src is the end of the input stream:
+// the trailing partial block is decoded (PaddedEncoding)
+// or reported as a CorruptInputError (StdEncoding).
+//
+// On error, ndst and nsrc report the data decoded so far.
+func (enc *Encoding) DecodePartial(dst, src []byte, flush bool) (ndst, nsrc int, err error) {
+	if dst == nil {
+		dst = []byte{} // no room, a nil dst would only count the decoded bytes
+	}
+	return decodePartial(enc, dst, src, flush)
+}
+
+// decodeString decodes the whole s as DecodePartial with flush set to true,
+// this is the encoding.DecodeFunc of DecodeString, DecodeInto and Valid.
+func (enc *Encoding) decodeString(dst []byte, s string) (ndst, nsrc int, err error) {
+	return decodePartial(enc, dst, s, true)
+}
+
+// decodePartial is DecodePartial for both strings and byte slices.
+// A nil dst only validates src: ndst counts the decoded bytes.
+func decodePartial[T string | []byte](enc *Encoding, dst []byte, src T, flush bool) (ndst, nsrc int, err error) {
+	var v uint64
+	nb := 0    // number of characters of the current block
+	start := 0 // position of the current block
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if enc.alphabet.Ignored(c) {
+			continue
+		}
+		if c > 127 || enc.alphabet.DecMap[c] == -1 {
+			return ndst, nsrc, CorruptInputError(i)
+		}
+
+		if nb == 0 {
+			start = i
+		}
+		v = v*Radix + uint64(enc.alphabet.DecMap[c])
+		nb++
+
+		if nb == 5 {
+			if dst != nil && len(dst)-ndst < 4 {
+				return ndst, nsrc, nil
+			}
+			if v > 0xffffffff {
+				return ndst, nsrc, CorruptInputError(start)
+			}
+			if dst != nil {
+				dst[ndst] = byte(v >> 24)
+				dst[ndst+1] = byte(v >> 16)
+				dst[ndst+2] = byte(v >> 8)
+				dst[ndst+3] = byte(v)
+			}
+			ndst += 4
+			nsrc = i + 1
+			v = 0
+			nb = 0
+		}
+	}
+
+	if nb == 0 {
+		return ndst, len(src), nil
+	}
+
+	if !flush {
+		return ndst, nsrc, nil
+	}
+
+	if !enc.padding || nb == 1 {
+		return ndst, nsrc, CorruptInputError(start)
+	}
+
+	if dst != nil && len(dst)-ndst < nb-1 {
+		return ndst, nsrc, nil
+	}
+
+	for j := nb; j < 5; j++ {
+		v = v*Radix + Radix - 1 // pad the partial block with the highest digit
+	}
+	if v > 0xffffffff {
+		return ndst, nsrc, CorruptInputError(start)
+	}
+
+	if dst == nil {
+		return ndst + nb - 1, len(src), nil
+	}
+	for j := 0; j < nb-1; j++ {
+		dst[ndst] = byte(v >> (24 - 8*j))
+		ndst++
+	}
+
+	return ndst, len(src), nil
+}
+
+// EncodeToString returns the Z85 encoding of src.
+func (enc *Encoding) EncodeToString(src []byte) string {
+	dst := make([]byte, enc.EncodedLen(len(src)))
+	enc.Encode(dst, src)
+	return string(dst)
+}
+
+// DecodeString returns the bytes represented by the Z85 string s.
+func (enc *Encoding) DecodeString(s string) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(s)))
+	n, nsrc, err := enc.decodeString(dst, s)
+	if err == nil && nsrc < len(s) {
+		err = io.ErrShortBuffer
+	}
+	return dst[:n], err
+}
+
+// DecodeBytes returns the bytes represented by the Z85 bytes src,
+// allocating the destination buffer at the right size.
+func (enc *Encoding) DecodeBytes(src []byte) ([]byte, error) {
+	dst := make([]byte, enc.DecodedLen(len(src)))
+	n, err := enc.Decode(dst, src)
+	return dst[:n], err
+}
+
+// DecodeInto decodes the Z85 string s into dst,
+// requiring exactly len(dst) decoded bytes, else the error is an *encoding.LengthError.
+// The content of dst is unspecified when an error occurs.
+func (enc *Encoding) DecodeInto(dst []byte, s string) error {
+	return encoding.DecodeBlocksInto(enc.decodeString, Radix, dst, s)
+}
+
+// Valid reports whether s is a valid Z85 string,
+// without allocating memory (see encoding.ValidBlocks).
+func (enc *Encoding) Valid(s string) bool {
+	_, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok
+}
+
+// ValidFor reports whether s is a valid Z85 string
+// decoding into at most n bytes, without allocating memory (see Valid).
+func (enc *Encoding) ValidFor(s string, n int) bool {
+	size, ok := encoding.ValidBlocks(enc.decodeString, s)
+	return ok && size <= n
+}
+
+// EncodedLen returns the length in bytes of the Z85 encoding of n bytes,
+// including the group separators and the line breaks, if any.
+func (enc *Encoding) EncodedLen(n int) int {
+	size := n / 4 * 5
+	if rem := n % 4; rem > 0 {
+		size += rem + 1
+	}
+	return enc.alphabet.FormattedLen(size)
+}
+
+// DecodedLen returns the length in bytes of the data
+// decoded from n Z85-encoded bytes.
+func (enc *Encoding) DecodedLen(n int) int {
+	if rem := n % 5; rem > 1 {
+		return n/5*4 + rem - 1
+	}
+	return n / 5 * 4
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2022 Teal.Finance contributors
+// This file is part of Teal.Finance/BaseXX licensed under the MIT License.
+// SPDX-License-Identifier: MIT
+
+package z85
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var cases = []struct {
+	name string
+	bin  []byte
+}{
+	{"nil", nil},
+	{"empty", []byte{}},
+	{"zero", []byte{0}},
+	{"one", []byte{1}},
+	{"two", []byte{2}},
+	{"ten", []byte{10}},
+	{"2zeros", []byte{0, 0}},
+	{"2ones", []byte{1, 1}},
+	{"3max", []byte{255, 255, 255}},
+	{"4max", []byte{255, 255, 255, 255}},
+	{"64zeros", make([]byte, 64)},
+	{"65zeros", make([]byte, 65)},
+	{"ascii", []byte("c'est une longue chanson")},
+	{"utf8", []byte("Garçon, un café très fort !")},
+}
+
+func TestEncode(t *testing.T) {
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			str := PaddedEncoding.EncodeToString(c.bin)
+			t.Logf("bin len=%d %v", len(c.bin), c.bin)
+			t.Logf("str len=%d %q", len(str), str)
+
+			if len(str) != PaddedEncoding.EncodedLen(len(c.bin)) {
+				t.Errorf("EncodedLen(%d) = %d, but got %d characters", len(c.bin), PaddedEncoding.EncodedLen(len(c.bin)), len(str))
+			}
+
+			got, err := PaddedEncoding.DecodeString(str)
+			if err != nil {
+				t.Errorf("Decode() error = %v", err)
+				return
+			}
+
+			if (len(got) == 0) && (len(c.bin) == 0) {
+				return
+			}
+
+			if !reflect.DeepEqual(got, c.bin) {
+				t.Errorf("Decode() = %v, want %v", got, c.bin)
+			}
+
+			if len(c.bin)%4 == 0 {
+				if std := StdEncoding.EncodeToString(c.bin); std != str {
+					t.Errorf("StdEncoding = %q but PaddedEncoding = %q", std, str)
+				}
+			}
+		})
+	}
+}
+
+// TestSpec uses the test vector of the ZeroMQ RFC 32.
+func TestSpec(t *testing.T) {
+	bin := []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}
+
+	if got := StdEncoding.EncodeToString(bin); got != "HelloWorld" {
+		t.Errorf("EncodeToString() = %q, want HelloWorld", got)
+	}
+
+	got, err := StdEncoding.DecodeString("HelloWorld")
+	if err != nil || !bytes.Equal(got, bin) {
+		t.Errorf("DecodeString(HelloWorld) = %x, %v", got, err)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		bin := make([]byte, rand.Intn(100))
+		rand.Read(bin)
+
+		got, err := PaddedEncoding.DecodeString(PaddedEncoding.EncodeToString(bin))
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("round-trip of %x = %x, %v", bin, got, err)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	cases := []struct {
+		enc    *Encoding
+		str    string
+		offset CorruptInputError
+	}{
+		{StdEncoding, "Hello", -1},
+		{StdEncoding, "HelloWorl", 5},        // strict length
+		{PaddedEncoding, "HelloW", 5},        // one trailing character cannot be decoded
+		{StdEncoding, "Hel\"o", 3},           // not in the alphabet
+		{StdEncoding, "Hel\xffo", 3},         // non-ASCII
+		{StdEncoding, "%nSc0HelloWorld", -1}, // 0xFFFFFFFF is "%nSc0"
+		{StdEncoding, "Hello%nSc1", 5},       // overflow
+		{PaddedEncoding, "%nSc1", 0},
+	}
+
+	for _, c := range cases {
+		_, err := c.enc.DecodeString(c.str)
+		if c.offset < 0 {
+			if err != nil {
+				t.Errorf("DecodeString(%q) unexpected error %v", c.str, err)
+			}
+			continue
+		}
+		var e CorruptInputError
+		if !errors.As(err, &e) || e != c.offset {
+			t.Errorf("DecodeString(%q) error = %v, want CorruptInputError(%d)", c.str, err, c.offset)
+		}
+	}
+}
+
+func TestDecode_ShortBuffer(t *testing.T) {
+	cases := []struct {
+		enc *Encoding
+		str string
+		n   int // size of dst
+	}{
+		{StdEncoding, "HelloWorld", 0},
+		{StdEncoding, "HelloWorld", 2},
+		{StdEncoding, "HelloWorld", 7},
+		{PaddedEncoding, "HelloWor", 5},
+	}
+
+	for _, c := range cases {
+		n, err := c.enc.Decode(make([]byte, c.n), []byte(c.str))
+		if !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("Decode(%d bytes, %q) = %d, %v, want io.ErrShortBuffer", c.n, c.str, n, err)
+		}
+	}
+
+	// trailing ignored characters are not an overflow of dst
+	enc := StdEncoding.WithIgnore(" ")
+	if n, err := enc.Decode(make([]byte, 8), []byte("HelloWorld  ")); n != 8 || err != nil {
+		t.Errorf("Decode(%q) = %d, %v, want 8, nil", "HelloWorld  ", n, err)
+	}
+}
+
+func TestEncode_Strict(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on input length not multiple of 4 did not occur")
+		}
+	}()
+
+	StdEncoding.EncodeToString([]byte{1, 2, 3})
+}
+
+func TestDecodePartial(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		bin := make([]byte, rand.Intn(100))
+		rand.Read(bin)
+		str := []byte(PaddedEncoding.EncodeToString(bin))
+		size := 1 + rand.Intn(12)
+
+		// feed the decoder with chunks of the given size
+		var got, pending []byte
+		dst := make([]byte, 4+rand.Intn(16))
+		for j := 0; j < len(str) || len(pending) > 0; j += size {
+			end := j + size
+			if end > len(str) {
+				end = len(str)
+			}
+			if j < len(str) {
+				pending = append(pending, str[j:end]...)
+			}
+			flush := end == len(str)
+
+			for {
+				ndst, nsrc, err := PaddedEncoding.DecodePartial(dst, pending, flush)
+				if err != nil {
+					t.Fatalf("DecodePartial(%q) error %v", pending, err)
+				}
+				got = append(got, dst[:ndst]...)
+				pending = pending[nsrc:]
+				if ndst == 0 && nsrc == 0 {
+					break
+				}
+			}
+
+			if flush {
+				break
+			}
+		}
+
+		if !bytes.Equal(got, bin) {
+			t.Fatalf("chunks of %d: decode(%q) = %x, want %x", size, str, got, bin)
+		}
+	}
+}
+
+func TestDecodePartial_Counts(t *testing.T) {
+	cases := []struct {
+		enc        *Encoding
+		str        string
+		dstLen     int
+		flush      bool
+		ndst, nsrc int
+	}{
+		{StdEncoding, "HelloWorl", 8, false, 4, 5}, // the partial block waits for more input
+		{StdEncoding, "HelloWorld", 3, true, 0, 0}, // dst too small
+		{StdEncoding, "HelloWorld", 7, true, 4, 5},
+		{PaddedEncoding, "HelloWor", 8, true, 6, 8},
+		{PaddedEncoding, "HelloWor", 5, true, 4, 5},
+	}
+
+	for _, c := range cases {
+		dst := make([]byte, c.dstLen)
+		ndst, nsrc, err := c.enc.DecodePartial(dst, []byte(c.str), c.flush)
+		if err != nil || ndst != c.ndst || nsrc != c.nsrc {
+			t.Errorf("DecodePartial(%q, flush=%v) = %d, %d, %v, want %d, %d, nil",
+				c.str, c.flush, ndst, nsrc, err, c.ndst, c.nsrc)
+		}
+	}
+}
+
+func TestWithGroups(t *testing.T) {
+	enc := PaddedEncoding.WithGroups(5, " ").WithWrap(12)
+
+	for i := 0; i < 200; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+
+		str := enc.EncodeToString(bin)
+		if want := PaddedEncoding.EncodeToString(bin); enc.Alphabet().Strip(str) != want {
+			t.Fatalf("EncodeToString(%x) = %q, want %q with separators", bin, str, want)
+		}
+
+		got, err := enc.DecodeString(str)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("round-trip of %x = %x, %v", bin, got, err)
+		}
+	}
+
+	if got := StdEncoding.WithGroups(5, " ").EncodeToString([]byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}); got != "Hello World" {
+		t.Errorf("EncodeToString() = %q, want %q", got, "Hello World")
+	}
+}
+
+func TestWithAliases(t *testing.T) {
+	enc := StdEncoding.WithAliases(map[byte]byte{'~': '.', '_': '-'})
+
+	for _, str := range []string{"0000.0000-", "0000~0000_"} {
+		got, err := enc.DecodeString(str)
+		if err != nil || !bytes.Equal(got, []byte{0, 0, 0, 62, 0, 0, 0, 63}) {
+			t.Errorf("DecodeString(%q) = %v, %v, want [0 0 0 62 0 0 0 63]", str, got, err)
+		}
+	}
+	if got := enc.EncodeToString([]byte{0, 0, 0, 62}); got != "0000." {
+		t.Errorf("EncodeToString() = %q, want 0000.", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on alias belonging to the alphabet did not occur")
+		}
+	}()
+	StdEncoding.WithAliases(map[byte]byte{'.': '-'})
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	// 85 characters without lower-case letters: the control characters are required
+	var alphabet []byte
+	for c := byte(1); len(alphabet) < Radix; c++ {
+		alphabet = append(alphabet, c)
+	}
+	enc := NewEncoding(string(alphabet)).WithPadding().CaseInsensitive()
+
+	for i := 0; i < 100; i++ {
+		bin := make([]byte, rand.Intn(50))
+		rand.Read(bin)
+
+		str := strings.ToLower(enc.EncodeToString(bin))
+		got, err := enc.DecodeString(str)
+		if err != nil || !bytes.Equal(got, bin) {
+			t.Fatalf("DecodeString(%q) = %x, %v, want %x", str, got, err, bin)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on alphabet having both letter cases did not occur")
+		}
+	}()
+	StdEncoding.CaseInsensitive()
+}